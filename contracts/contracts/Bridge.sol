@@ -107,7 +107,7 @@ contract Bridge is Pausable, AccessControl, SafeMath {
     }
 
     function deposit(uint8 destinationDomainID, bytes32 resourceID, bytes calldata data) external payable {
-        require(msg.value == _fee, "Incorrect fee supplied");
+        require(msg.value >= _fee, "Incorrect fee supplied");
 
         address handler = _resourceIDToHandlerAddress[resourceID];
         require(handler != address(0), "resourceID not mapped to handler");
@@ -116,7 +116,8 @@ contract Bridge is Pausable, AccessControl, SafeMath {
         address sender = _msgSender();
 
         IDepositExecute depositHandler = IDepositExecute(handler);
-        bytes memory handlerResponse = depositHandler.deposit(resourceID, sender, data);
+        // value above the fee is the deposited native currency and is forwarded to the handler
+        bytes memory handlerResponse = depositHandler.deposit{value: msg.value - _fee}(resourceID, sender, data);
 
         emit Deposit(destinationDomainID, resourceID, depositNonce, sender, data, handlerResponse);
     }
@@ -15,7 +15,9 @@ contract ERC20Handler is IDepositExecute, HandlerHelpers, ERC20Safe {
         bytes32 resourceID,
         address depositer,
         bytes   calldata data
-    ) external override onlyBridge returns (bytes memory) {
+    ) external payable override onlyBridge returns (bytes memory) {
+        require(msg.value == 0, "native currency not accepted");
+
         uint256        amount;
         (amount) = abi.decode(data, (uint));
 
@@ -0,0 +1,62 @@
+pragma solidity 0.6.12;
+pragma experimental ABIEncoderV2;
+
+import "./interfaces/IDepositExecute.sol";
+import "./HandlerHelpers.sol";
+import "./ERC20Safe.sol";
+
+/**
+    @notice Locks native currency forwarded by the bridge on deposit and releases it on execution.
+    On chains where the resource is mapped to a burnable wrapped token, the wrapped token is
+    burned on deposit and minted on execution instead.
+ */
+contract NativeHandler is IDepositExecute, HandlerHelpers, ERC20Safe {
+    constructor(
+        address          bridgeAddress
+    ) public HandlerHelpers(bridgeAddress) {
+    }
+
+    function deposit(
+        bytes32 resourceID,
+        address depositer,
+        bytes   calldata data
+    ) external payable override onlyBridge returns (bytes memory) {
+        uint256        amount;
+        (amount) = abi.decode(data, (uint));
+
+        address tokenAddress = _resourceIDToTokenContractAddress[resourceID];
+        require(_contractWhitelist[tokenAddress], "provided tokenAddress is not whitelisted");
+
+        if (_burnList[tokenAddress]) {
+            require(msg.value == 0, "native currency not accepted");
+            burnERC20(tokenAddress, depositer, amount);
+        } else {
+            require(msg.value == amount, "incorrect native amount supplied");
+        }
+    }
+
+    function executeProposal(bytes32 resourceID, bytes calldata data) external override onlyBridge {
+        uint256       amount;
+        uint256       lenDestinationRecipientAddress;
+        bytes  memory destinationRecipientAddress;
+
+        (amount, lenDestinationRecipientAddress) = abi.decode(data, (uint, uint));
+        destinationRecipientAddress = bytes(data[64:64 + lenDestinationRecipientAddress]);
+
+        bytes20 recipientAddress;
+        address tokenAddress = _resourceIDToTokenContractAddress[resourceID];
+
+        assembly {
+            recipientAddress := mload(add(destinationRecipientAddress, 0x20))
+        }
+
+        require(_contractWhitelist[tokenAddress], "provided tokenAddress is not whitelisted");
+
+        if (_burnList[tokenAddress]) {
+            mintERC20(tokenAddress, address(recipientAddress), amount);
+        } else {
+            (bool success, ) = address(recipientAddress).call{value: amount}("");
+            require(success, "native currency transfer failed");
+        }
+    }
+}
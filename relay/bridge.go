@@ -1,26 +1,36 @@
 package bridge
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/mpetrun5/diplomski-projekt/chains/evm"
 	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/contracts/bridge"
 	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/evmclient"
 	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/evmgaspricer"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/evmsigner"
 	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/evmtransaction"
 	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/transactor/signAndSend"
 	"github.com/mpetrun5/diplomski-projekt/chains/evm/listener"
 	"github.com/mpetrun5/diplomski-projekt/chains/evm/voter"
 	"github.com/mpetrun5/diplomski-projekt/config"
 	"github.com/mpetrun5/diplomski-projekt/config/chain"
-	"github.com/mpetrun5/diplomski-projekt/crypto/secp256k1"
 	"github.com/mpetrun5/diplomski-projekt/flags"
+	"github.com/mpetrun5/diplomski-projekt/health"
+	"github.com/mpetrun5/diplomski-projekt/keystore"
 	"github.com/mpetrun5/diplomski-projekt/lvldb"
+	"github.com/mpetrun5/diplomski-projekt/metrics"
 	"github.com/mpetrun5/diplomski-projekt/relayer"
+	"github.com/mpetrun5/diplomski-projekt/relayer/processors"
+	"github.com/mpetrun5/diplomski-projekt/relayer/ratelimit"
 	"github.com/mpetrun5/diplomski-projekt/store"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog/log"
 	"github.com/spf13/viper"
 )
 
@@ -29,25 +39,54 @@ func Run() error {
 	stopChn := make(chan struct{})
 
 	configuration, err := config.GetConfig(viper.GetString(flags.ConfigFlagName))
+	if err != nil {
+		return err
+	}
+	messageProcessors, err := processors.NewProcessors(configuration.RelayerConfig.Processors)
+	if err != nil {
+		return err
+	}
+	rateLimits, err := ratelimit.NewLimits(configuration.RelayerConfig.RateLimits)
+	if err != nil {
+		return err
+	}
+
 	db, err := lvldb.NewLvlDB(viper.GetString(flags.BlockstoreFlagName))
 	if err != nil {
 		panic(err)
 	}
 	blockstore := store.NewBlockStore(db)
+	queueStore := store.NewMessageQueueStore(db)
+	deadLetterStore := store.NewDeadLetterStore(db)
+	rateLimitStore := store.NewRateLimitStore(db)
+	unhandledDepositStore := store.NewUnhandledDepositStore(db)
+	if len(rateLimits) > 0 {
+		rateLimiter := ratelimit.NewRateLimiter(rateLimits, rateLimitStore)
+		messageProcessors = append(messageProcessors, rateLimiter.Process)
+	}
+
+	relayerMetrics := metrics.NewRelayerMetrics(prometheus.DefaultRegisterer)
+	metricsServer := metrics.NewServer(configuration.RelayerConfig.MetricsPort)
+	go func() {
+		if err := metricsServer.Serve(); err != nil {
+			errChn <- err
+		}
+	}()
 
 	chains := []relayer.RelayedChain{}
+	healthChains := []health.Chain{}
 	for _, chainConfig := range configuration.ChainConfigs {
 		config, err := chain.NewEVMConfig(chainConfig)
 		if err != nil {
 			panic(err)
 		}
 
-		kp, err := secp256k1.GenerateKeypair()
+		signer, err := newSigner(config.GeneralChainConfig)
 		if err != nil {
 			panic(err)
 		}
 
-		client, err := evmclient.NewEVMClientFromParams(config.GeneralChainConfig.Endpoint, kp.PrivateKey())
+		client, err := evmclient.NewEVMClientFromParams(config.GeneralChainConfig.Endpoint, signer)
 		if err != nil {
 			panic(err)
 		}
@@ -57,19 +96,68 @@ func Run() error {
 
 		eventHandler := listener.NewETHEventHandler(*bridgeContract)
 		eventHandler.RegisterEventHandler(config.Erc20Handler, listener.Erc20EventHandler)
-		evmListener := listener.NewEVMListener(client, eventHandler, common.HexToAddress(config.Bridge))
+		eventHandler.RegisterEventHandler(config.Erc721Handler, listener.Erc721EventHandler)
+		eventHandler.RegisterEventHandler(config.Erc1155Handler, listener.Erc1155EventHandler)
+		eventHandler.RegisterEventHandler(config.NativeHandler, listener.NativeEventHandler)
+		eventHandler.RegisterEventHandler(config.GenericHandler, listener.GenericEventHandler)
+		var subscriber listener.HeadSubscriber
+		if config.Subscribe {
+			subscriber = client
+		}
+		evmListener := listener.NewEVMListener(
+			client,
+			eventHandler,
+			common.HexToAddress(config.Bridge),
+			queueStore,
+			rateLimitStore,
+			deadLetterStore,
+			unhandledDepositStore,
+			relayerMetrics,
+			subscriber,
+			config.BlockConfirmations,
+			config.BlockRetryInterval,
+			config.BlockInterval,
+			config.BlockTag,
+		)
 
 		mh := voter.NewEVMMessageHandler(*bridgeContract)
 		mh.RegisterMessageHandler(config.Erc20Handler, voter.ERC20MessageHandler)
+		mh.RegisterMessageHandler(config.Erc721Handler, voter.ERC721MessageHandler)
+		mh.RegisterMessageHandler(config.Erc1155Handler, voter.ERC1155MessageHandler)
+		mh.RegisterMessageHandler(config.NativeHandler, voter.NativeMessageHandler)
+		mh.RegisterMessageHandler(config.GenericHandler, voter.GenericMessageHandler)
 
 		var evmVoter *voter.EVMVoter
 		evmVoter = voter.NewVoter(mh, bridgeContract)
 
-		chains = append(chains, evm.NewEVMChain(evmListener, evmVoter, blockstore, config))
+		evmChain := evm.NewEVMChain(evmListener, evmVoter, blockstore, config, client, bridgeContract)
+		chains = append(chains, evmChain)
+		healthChains = append(healthChains, evmChain)
+		go relayerMetrics.PollRelayerBalance(stopChn, client, *config.GeneralChainConfig.Id, client.RelayerAddress())
 	}
 
-	r := relayer.NewRelayer(chains)
-	go r.Start(stopChn, errChn)
+	healthServer := health.NewHealthServer(healthChains, configuration.RelayerConfig.StallThreshold, configuration.RelayerConfig.HealthPort)
+	go func() {
+		if err := healthServer.Serve(); err != nil {
+			errChn <- err
+		}
+	}()
+
+	r := relayer.NewRelayer(
+		chains,
+		relayerMetrics,
+		queueStore,
+		deadLetterStore,
+		rateLimitStore,
+		messageProcessors,
+		configuration.RelayerConfig.Workers,
+		configuration.RelayerConfig.QueueSize,
+	)
+	relayerStopped := make(chan struct{})
+	go func() {
+		r.Start(stopChn, errChn)
+		close(relayerStopped)
+	}()
 
 	sysErr := make(chan os.Signal, 1)
 	signal.Notify(
@@ -79,9 +167,71 @@ func Run() error {
 		syscall.SIGHUP,
 		syscall.SIGQUIT)
 
+	var runErr error
 	select {
-	case err := <-errChn:
-		close(stopChn)
-		return err
+	case runErr = <-errChn:
+		log.Error().Err(runErr).Msg("Relayer failed, shutting down")
+	case sig := <-sysErr:
+		log.Info().Msgf("Received %s signal, shutting down", sig)
+	}
+	close(stopChn)
+
+	servers := []server{metricsServer, healthServer}
+	return shutdown(relayerStopped, servers, db, configuration.RelayerConfig.ShutdownTimeout, runErr)
+}
+
+// server is an HTTP server stopped on shutdown
+type server interface {
+	Shutdown(ctx context.Context) error
+}
+
+// shutdown waits for the relayer to drain in-flight messages, stops the servers and closes
+// the store, all within the timeout. Returned error is nil only if the relayer stopped cleanly.
+func shutdown(relayerStopped <-chan struct{}, servers []server, db *lvldb.LVLDB, timeout time.Duration, runErr error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var err error
+	select {
+	case <-relayerStopped:
+		log.Info().Msg("Relayer stopped")
+	case <-ctx.Done():
+		err = fmt.Errorf("relayer did not stop in %s", timeout)
+		log.Error().Err(err).Msg("Shutdown timed out")
+	}
+
+	for _, s := range servers {
+		if shutdownErr := s.Shutdown(ctx); shutdownErr != nil {
+			log.Error().Err(shutdownErr).Msg("Failed stopping server")
+		}
+	}
+
+	if closeErr := db.Close(); closeErr != nil {
+		log.Error().Err(closeErr).Msg("Failed closing blockstore")
+		if err == nil {
+			err = closeErr
+		}
+	}
+
+	if runErr != nil {
+		return runErr
+	}
+	return err
+}
+
+// newSigner connects to the remote signer if one is configured for the chain,
+// otherwise it loads the relayer key from the keystore
+func newSigner(config chain.GeneralChainConfig) (evmclient.Signer, error) {
+	if config.RemoteSigner != "" {
+		if !common.IsHexAddress(config.From) {
+			return nil, fmt.Errorf("invalid remote signer account %s", config.From)
+		}
+		return evmsigner.DialRemoteSigner(config.RemoteSigner, common.HexToAddress(config.From))
+	}
+
+	kp, err := keystore.KeypairFromAddress(config.From, config.KeystorePath, config.Insecure)
+	if err != nil {
+		return nil, err
 	}
+	return evmsigner.NewLocalSigner(kp), nil
 }
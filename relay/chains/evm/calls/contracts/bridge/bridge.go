@@ -45,12 +45,67 @@ func (c *BridgeContract) AdminSetResource(
 	)
 }
 
+func (c *BridgeContract) AdminSetGenericResource(
+	handler common.Address,
+	rID [32]byte,
+	addr common.Address,
+	depositFunctionSig [4]byte,
+	depositerOffset *big.Int,
+	executeFunctionSig [4]byte,
+	opts transactor.TransactOptions,
+) (*common.Hash, error) {
+	log.Debug().Msgf("Setting generic resource %s", hexutil.Encode(rID[:]))
+	return c.ExecuteTransaction(
+		"adminSetGenericResource",
+		opts,
+		handler, rID, addr, depositFunctionSig, depositerOffset, executeFunctionSig,
+	)
+}
+
+func (c *BridgeContract) AdminChangeFee(
+	newFee *big.Int,
+	opts transactor.TransactOptions,
+) (*common.Hash, error) {
+	log.Debug().Msgf("Changing fee to %s", newFee.String())
+	return c.ExecuteTransaction(
+		"adminChangeFee",
+		opts,
+		newFee,
+	)
+}
+
+// TransferFunds withdraws collected fees from the bridge, sending amounts[i] to addrs[i]
+func (c *BridgeContract) TransferFunds(
+	addrs []common.Address,
+	amounts []*big.Int,
+	opts transactor.TransactOptions,
+) (*common.Hash, error) {
+	log.Debug().Msgf("Transferring fees to %d accounts", len(addrs))
+	return c.ExecuteTransaction(
+		"transferFunds",
+		opts,
+		addrs, amounts,
+	)
+}
+
+// deposit calls bridge deposit with the bridge fee added to the transaction value.
+// The bridge rejects deposits paying less than the fee and forwards any value above it
+// to the handler, so opts.Value is the deposited native currency.
 func (c *BridgeContract) deposit(
 	resourceID [32]byte,
 	destDomainID uint8,
 	data []byte,
 	opts transactor.TransactOptions,
 ) (*common.Hash, error) {
+	fee, err := c.Fee()
+	if err != nil {
+		return nil, err
+	}
+	if opts.Value == nil {
+		opts.Value = big.NewInt(0)
+	}
+	opts.Value = new(big.Int).Add(opts.Value, fee)
+
 	return c.ExecuteTransaction(
 		"deposit",
 		opts,
@@ -74,6 +129,79 @@ func (c *BridgeContract) Erc20Deposit(
 	return txHash, err
 }
 
+// NativeDeposit deposits amount of native currency by attaching it, together with the bridge fee,
+// as the transaction value. The deposit data shares the ERC20 layout so the handler knows
+// the amount it has to release.
+func (c *BridgeContract) NativeDeposit(
+	recipient common.Address,
+	amount *big.Int,
+	resourceID [32]byte,
+	destDomainID uint8,
+	opts transactor.TransactOptions,
+) (*common.Hash, error) {
+	data := deposit.ConstructErc20DepositData(recipient.Bytes(), amount)
+	opts.Value = amount
+	txHash, err := c.deposit(resourceID, destDomainID, data, opts)
+	if err != nil {
+		log.Error().Err(err)
+		return nil, err
+	}
+	return txHash, err
+}
+
+func (c *BridgeContract) Erc721Deposit(
+	tokenId *big.Int,
+	metadata string,
+	recipient common.Address,
+	resourceID [32]byte,
+	destDomainID uint8,
+	opts transactor.TransactOptions,
+) (*common.Hash, error) {
+	data := deposit.ConstructErc721DepositData(recipient.Bytes(), tokenId, []byte(metadata))
+	txHash, err := c.deposit(resourceID, destDomainID, data, opts)
+	if err != nil {
+		log.Error().Err(err)
+		return nil, err
+	}
+	return txHash, err
+}
+
+func (c *BridgeContract) Erc1155Deposit(
+	tokenIDs []*big.Int,
+	amounts []*big.Int,
+	recipient common.Address,
+	transferData []byte,
+	resourceID [32]byte,
+	destDomainID uint8,
+	opts transactor.TransactOptions,
+) (*common.Hash, error) {
+	data, err := deposit.ConstructErc1155DepositData(recipient.Bytes(), tokenIDs, amounts, transferData)
+	if err != nil {
+		return nil, err
+	}
+	txHash, err := c.deposit(resourceID, destDomainID, data, opts)
+	if err != nil {
+		log.Error().Err(err)
+		return nil, err
+	}
+	return txHash, err
+}
+
+func (c *BridgeContract) GenericDeposit(
+	metadata []byte,
+	resourceID [32]byte,
+	destDomainID uint8,
+	opts transactor.TransactOptions,
+) (*common.Hash, error) {
+	data := deposit.ConstructGenericDepositData(metadata)
+	txHash, err := c.deposit(resourceID, destDomainID, data, opts)
+	if err != nil {
+		log.Error().Err(err)
+		return nil, err
+	}
+	return txHash, err
+}
+
 func (c *BridgeContract) VoteProposal(
 	proposal *proposal.Proposal,
 	opts transactor.TransactOptions,
@@ -95,3 +223,24 @@ func (c *BridgeContract) GetHandlerAddressForResourceID(
 	out := *abi.ConvertType(res[0], new(common.Address)).(*common.Address)
 	return out, nil
 }
+
+func (c *BridgeContract) IsRelayer(
+	relayerAddress common.Address,
+) (bool, error) {
+	res, err := c.CallContract("isRelayer", relayerAddress)
+	if err != nil {
+		return false, err
+	}
+	out := abi.ConvertType(res[0], new(bool)).(*bool)
+	return *out, nil
+}
+
+// Fee returns the fee, in wei, that has to be paid with every deposit
+func (c *BridgeContract) Fee() (*big.Int, error) {
+	res, err := c.CallContract("_fee")
+	if err != nil {
+		return nil, err
+	}
+	out := abi.ConvertType(res[0], new(big.Int)).(*big.Int)
+	return out, nil
+}
@@ -3,9 +3,23 @@ package deposit
 import (
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common/math"
 )
 
+// erc1155DepositArguments describe ERC1155 deposit data, which is ABI encoded as
+// (uint256[] tokenIDs, uint256[] amounts, bytes recipient, bytes transferData)
+var erc1155DepositArguments = func() abi.Arguments {
+	uint256Array, _ := abi.NewType("uint256[]", "", nil)
+	bytesType, _ := abi.NewType("bytes", "", nil)
+	return abi.Arguments{
+		{Name: "tokenIDs", Type: uint256Array},
+		{Name: "amounts", Type: uint256Array},
+		{Name: "recipient", Type: bytesType},
+		{Name: "transferData", Type: bytesType},
+	}
+}()
+
 func ConstructErc20DepositData(destRecipient []byte, amount *big.Int) []byte {
 	var data []byte
 	data = append(data, math.PaddedBigBytes(amount, 32)...)
@@ -13,3 +27,46 @@ func ConstructErc20DepositData(destRecipient []byte, amount *big.Int) []byte {
 	data = append(data, destRecipient...)
 	return data
 }
+
+func ConstructErc721DepositData(destRecipient []byte, tokenId *big.Int, metadata []byte) []byte {
+	var data []byte
+	data = append(data, math.PaddedBigBytes(tokenId, 32)...)
+	data = append(data, math.PaddedBigBytes(big.NewInt(int64(len(destRecipient))), 32)...)
+	data = append(data, destRecipient...)
+	data = append(data, math.PaddedBigBytes(big.NewInt(int64(len(metadata))), 32)...)
+	data = append(data, metadata...)
+	return data
+}
+
+func ConstructGenericDepositData(metadata []byte) []byte {
+	var data []byte
+	data = append(data, math.PaddedBigBytes(big.NewInt(int64(len(metadata))), 32)...)
+	data = append(data, metadata...)
+	return data
+}
+
+func ConstructErc1155DepositData(destRecipient []byte, tokenIDs, amounts []*big.Int, transferData []byte) ([]byte, error) {
+	return erc1155DepositArguments.Pack(tokenIDs, amounts, destRecipient, transferData)
+}
+
+// Erc1155DepositData is decoded ERC1155 deposit data
+type Erc1155DepositData struct {
+	TokenIDs     []*big.Int
+	Amounts      []*big.Int
+	Recipient    []byte
+	TransferData []byte
+}
+
+func ParseErc1155DepositData(data []byte) (*Erc1155DepositData, error) {
+	values, err := erc1155DepositArguments.Unpack(data)
+	if err != nil {
+		return nil, err
+	}
+
+	depositData := &Erc1155DepositData{}
+	err = erc1155DepositArguments.Copy(depositData, values)
+	if err != nil {
+		return nil, err
+	}
+	return depositData, nil
+}
@@ -0,0 +1,47 @@
+package erc1155
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/contracts"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/transactor"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/consts"
+	"github.com/rs/zerolog/log"
+)
+
+type ERC1155Contract struct {
+	contracts.Contract
+}
+
+func NewERC1155Contract(
+	client calls.ContractCallerDispatcher,
+	erc1155ContractAddress common.Address,
+	transactor transactor.Transactor,
+) *ERC1155Contract {
+	a, _ := abi.JSON(strings.NewReader(consts.ERC1155PresetMinterPauserABI))
+	return &ERC1155Contract{contracts.NewContract(erc1155ContractAddress, a, nil, client, transactor)}
+}
+
+// SetApprovalForAll approves the operator, usually the ERC1155 handler, to transfer all tokens of the sender
+func (c *ERC1155Contract) SetApprovalForAll(
+	operator common.Address,
+	approved bool,
+	opts transactor.TransactOptions,
+) (*common.Hash, error) {
+	log.Debug().Msgf("Setting approval for all tokens of %s to %v", operator.String(), approved)
+	return c.ExecuteTransaction("setApprovalForAll", opts, operator, approved)
+}
+
+func (c *ERC1155Contract) BalanceOf(account common.Address, tokenID *big.Int) (*big.Int, error) {
+	res, err := c.CallContract("balanceOf", account, tokenID)
+	if err != nil {
+		return nil, err
+	}
+	b := abi.ConvertType(res[0], new(big.Int)).(*big.Int)
+	return b, nil
+}
@@ -0,0 +1,65 @@
+package erc721
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/contracts"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/transactor"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/consts"
+	"github.com/rs/zerolog/log"
+)
+
+type ERC721Contract struct {
+	contracts.Contract
+}
+
+func NewERC721Contract(
+	client calls.ContractCallerDispatcher,
+	erc721ContractAddress common.Address,
+	transactor transactor.Transactor,
+) *ERC721Contract {
+	a, _ := abi.JSON(strings.NewReader(consts.ERC721MinterBurnerPauserABI))
+	return &ERC721Contract{contracts.NewContract(erc721ContractAddress, a, nil, client, transactor)}
+}
+
+func (c *ERC721Contract) Mint(
+	tokenId *big.Int,
+	metadata string,
+	destination common.Address,
+	opts transactor.TransactOptions,
+) (*common.Hash, error) {
+	log.Debug().Msgf("Minting token %s to %s", tokenId.String(), destination.String())
+	return c.ExecuteTransaction("mint", opts, destination, tokenId, metadata)
+}
+
+func (c *ERC721Contract) Approve(
+	tokenId *big.Int,
+	recipient common.Address,
+	opts transactor.TransactOptions,
+) (*common.Hash, error) {
+	log.Debug().Msgf("Approving %s token for %s", tokenId.String(), recipient.String())
+	return c.ExecuteTransaction("approve", opts, recipient, tokenId)
+}
+
+func (c *ERC721Contract) Owner(tokenId *big.Int) (*common.Address, error) {
+	res, err := c.CallContract("ownerOf", tokenId)
+	if err != nil {
+		return nil, err
+	}
+	owner := abi.ConvertType(res[0], new(common.Address)).(*common.Address)
+	return owner, nil
+}
+
+func (c *ERC721Contract) TokenURI(tokenId *big.Int) (string, error) {
+	res, err := c.CallContract("tokenURI", tokenId)
+	if err != nil {
+		return "", err
+	}
+	uri := *abi.ConvertType(res[0], new(string)).(*string)
+	return uri, nil
+}
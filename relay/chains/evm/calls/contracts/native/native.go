@@ -0,0 +1,49 @@
+package native
+
+import (
+	"strings"
+
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/contracts"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/transactor"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/consts"
+)
+
+// NativeHandlerContract locks native currency on deposit and on execution either releases
+// locked currency or mints the wrapped token registered for the resource
+type NativeHandlerContract struct {
+	contracts.Contract
+}
+
+func NewNativeHandlerContract(
+	client calls.ContractCallerDispatcher,
+	nativeHandlerContractAddress common.Address,
+	transactor transactor.Transactor,
+) *NativeHandlerContract {
+	a, _ := abi.JSON(strings.NewReader(consts.NativeHandlerABI))
+	return &NativeHandlerContract{contracts.NewContract(nativeHandlerContractAddress, a, nil, client, transactor)}
+}
+
+// WrappedToken returns the wrapped token contract registered for the resource
+func (c *NativeHandlerContract) WrappedToken(resourceID [32]byte) (common.Address, error) {
+	res, err := c.CallContract("_resourceIDToTokenContractAddress", resourceID)
+	if err != nil {
+		return common.Address{}, err
+	}
+	out := *abi.ConvertType(res[0], new(common.Address)).(*common.Address)
+	return out, nil
+}
+
+// IsBurnable returns true if the handler mints and burns the wrapped token instead of
+// releasing locked currency
+func (c *NativeHandlerContract) IsBurnable(token common.Address) (bool, error) {
+	res, err := c.CallContract("_burnList", token)
+	if err != nil {
+		return false, err
+	}
+	out := abi.ConvertType(res[0], new(bool)).(*bool)
+	return *out, nil
+}
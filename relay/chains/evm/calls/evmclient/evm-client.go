@@ -2,7 +2,6 @@ package evmclient
 
 import (
 	"context"
-	"crypto/ecdsa"
 	"errors"
 	"fmt"
 	"math/big"
@@ -11,7 +10,6 @@ import (
 	"time"
 
 	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/consts"
-	"github.com/mpetrun5/diplomski-projekt/crypto/secp256k1"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
@@ -32,7 +30,7 @@ const (
 
 type EVMClient struct {
 	*ethclient.Client
-	kp        *secp256k1.Keypair
+	signer    Signer
 	rpClient  *rpc.Client
 	nonce     *big.Int
 	nonceLock sync.Mutex
@@ -45,14 +43,24 @@ type DepositLogs struct {
 	SenderAddress       common.Address
 	Data                []byte
 	HandlerResponse     []byte
+	BlockNumber         uint64
+	TxHash              common.Hash
+	Index               uint
+}
+
+// Signer signs transactions and hashes on behalf of the relayer account
+type Signer interface {
+	CommonAddress() common.Address
+	SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+	SignHash(hash []byte) ([]byte, error)
 }
 
 type CommonTransaction interface {
 	Hash() common.Hash
-	RawWithSignature(key *ecdsa.PrivateKey, domainID *big.Int) ([]byte, error)
+	RawWithSignature(signer Signer, domainID *big.Int) ([]byte, error)
 }
 
-func NewEVMClientFromParams(url string, privateKey *ecdsa.PrivateKey) (*EVMClient, error) {
+func NewEVMClientFromParams(url string, signer Signer) (*EVMClient, error) {
 	rpcClient, err := rpc.DialContext(context.TODO(), url)
 	if err != nil {
 		return nil, err
@@ -60,14 +68,19 @@ func NewEVMClientFromParams(url string, privateKey *ecdsa.PrivateKey) (*EVMClien
 	c := &EVMClient{}
 	c.Client = ethclient.NewClient(rpcClient)
 	c.rpClient = rpcClient
-	c.kp = secp256k1.NewKeypair(*privateKey)
+	c.signer = signer
 	return c, nil
 }
 
 // LatestBlock returns the latest block from the current chain
 func (c *EVMClient) LatestBlock() (*big.Int, error) {
+	return c.TaggedBlock(toBlockNumArg(nil))
+}
+
+// TaggedBlock returns the number of the block with the tag, such as "safe" or "finalized"
+func (c *EVMClient) TaggedBlock(tag string) (*big.Int, error) {
 	var head *headerNumber
-	err := c.rpClient.CallContext(context.Background(), &head, "eth_getBlockByNumber", toBlockNumArg(nil), false)
+	err := c.rpClient.CallContext(context.Background(), &head, "eth_getBlockByNumber", tag, false)
 	if err == nil && head == nil {
 		err = ethereum.NotFound
 	}
@@ -121,6 +134,9 @@ func (c *EVMClient) FetchDepositLogs(ctx context.Context, contractAddress common
 			continue
 		}
 		log.Debug().Msgf("Found deposit log in block: %d, TxHash: %s, contractAddress: %s, sender: %s", l.BlockNumber, l.TxHash, l.Address, dl.SenderAddress)
+		dl.BlockNumber = l.BlockNumber
+		dl.TxHash = l.TxHash
+		dl.Index = l.Index
 
 		depositLogs = append(depositLogs, dl)
 	}
@@ -128,6 +144,12 @@ func (c *EVMClient) FetchDepositLogs(ctx context.Context, contractAddress common
 	return depositLogs, nil
 }
 
+// SubscribeDepositLogs subscribes to deposit logs emitted by the bridge contract.
+// Subscriptions require a websocket or IPC endpoint.
+func (c *EVMClient) SubscribeDepositLogs(ctx context.Context, contractAddress common.Address, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return c.SubscribeFilterLogs(ctx, buildQuery(contractAddress, string(Deposit), nil, nil), ch)
+}
+
 func (c *EVMClient) UnpackDepositEventLog(abi abi.ABI, data []byte) (*DepositLogs, error) {
 	var dl DepositLogs
 
@@ -173,8 +195,13 @@ func (c *EVMClient) PendingCallContract(ctx context.Context, callArgs map[string
 	return hex, nil
 }
 
+// From returns the address calls are made from, which is the zero address
+// for clients created without a signer
 func (c *EVMClient) From() common.Address {
-	return c.kp.CommonAddress()
+	if c.signer == nil {
+		return common.Address{}
+	}
+	return c.signer.CommonAddress()
 }
 
 func (c *EVMClient) SignAndSendTransaction(ctx context.Context, tx CommonTransaction) (common.Hash, error) {
@@ -184,7 +211,7 @@ func (c *EVMClient) SignAndSendTransaction(ctx context.Context, tx CommonTransac
 		// Probably chain does not support chainID eg. CELO
 		id = nil
 	}
-	rawTx, err := tx.RawWithSignature(c.kp.PrivateKey(), id)
+	rawTx, err := tx.RawWithSignature(c.signer, id)
 	if err != nil {
 		return common.Hash{}, err
 	}
@@ -196,7 +223,7 @@ func (c *EVMClient) SignAndSendTransaction(ctx context.Context, tx CommonTransac
 }
 
 func (c *EVMClient) RelayerAddress() common.Address {
-	return c.kp.CommonAddress()
+	return c.signer.CommonAddress()
 }
 
 func (c *EVMClient) LockNonce() {
@@ -211,7 +238,7 @@ func (c *EVMClient) UnsafeNonce() (*big.Int, error) {
 	var err error
 	for i := 0; i <= 10; i++ {
 		if c.nonce == nil {
-			nonce, err := c.PendingNonceAt(context.Background(), c.kp.CommonAddress())
+			nonce, err := c.PendingNonceAt(context.Background(), c.signer.CommonAddress())
 			if err != nil {
 				time.Sleep(1 * time.Second)
 				continue
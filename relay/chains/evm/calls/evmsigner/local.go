@@ -0,0 +1,40 @@
+package evmsigner
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/mpetrun5/diplomski-projekt/crypto/secp256k1"
+)
+
+// LocalSigner signs transactions with a keypair held in process memory
+type LocalSigner struct {
+	kp *secp256k1.Keypair
+}
+
+func NewLocalSigner(kp *secp256k1.Keypair) *LocalSigner {
+	return &LocalSigner{kp: kp}
+}
+
+func (s *LocalSigner) CommonAddress() common.Address {
+	return s.kp.CommonAddress()
+}
+
+// SignTx signs the transaction with the latest signer for the provided chain ID.
+// If chainID is nil the transaction is signed without replay protection.
+func (s *LocalSigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), s.kp.PrivateKey())
+}
+
+// SignHash signs the EIP-191 personal message hash of the provided data
+func (s *LocalSigner) SignHash(hash []byte) ([]byte, error) {
+	sig, err := crypto.Sign(accounts.TextHash(hash), s.kp.PrivateKey())
+	if err != nil {
+		return nil, err
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+	return sig, nil
+}
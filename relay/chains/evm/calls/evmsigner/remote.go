@@ -0,0 +1,159 @@
+package evmsigner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	signTransactionMethod = "account_signTransaction"
+	signDataMethod        = "account_signData"
+	textPlainMimeType     = "text/plain"
+)
+
+var requestTimeout = 60 * time.Second
+
+// signTransactionArgs mirrors transaction arguments accepted by Clef account_signTransaction method
+type signTransactionArgs struct {
+	From     common.Address  `json:"from"`
+	To       *common.Address `json:"to"`
+	Gas      hexutil.Uint64  `json:"gas"`
+	GasPrice *hexutil.Big    `json:"gasPrice"`
+	Value    *hexutil.Big    `json:"value"`
+	Nonce    hexutil.Uint64  `json:"nonce"`
+	Data     hexutil.Bytes   `json:"data"`
+	ChainID  *hexutil.Big    `json:"chainId"`
+}
+
+type signTransactionResult struct {
+	Raw hexutil.Bytes `json:"raw"`
+}
+
+// RemoteSigner delegates signing to an external signer exposing Clef compatible JSON-RPC API
+// so that relayer keys never have to be loaded into relayer memory
+type RemoteSigner struct {
+	client  *rpc.Client
+	address common.Address
+}
+
+func NewRemoteSigner(client *rpc.Client, address common.Address) *RemoteSigner {
+	return &RemoteSigner{client: client, address: address}
+}
+
+// DialRemoteSigner connects to the external signer on the provided URL
+func DialRemoteSigner(url string, address common.Address) (*RemoteSigner, error) {
+	client, err := rpc.DialContext(context.TODO(), url)
+	if err != nil {
+		return nil, err
+	}
+	return NewRemoteSigner(client, address), nil
+}
+
+func (s *RemoteSigner) CommonAddress() common.Address {
+	return s.address
+}
+
+// SignTx requests the external signer to sign the transaction and verifies that
+// the returned transaction is the one requested and signed by the expected account.
+// The chain ID is required because external signers always sign with replay protection.
+func (s *RemoteSigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	if chainID == nil {
+		return nil, errors.New("remote signer requires chain ID")
+	}
+
+	args := signTransactionArgs{
+		From:     s.address,
+		To:       tx.To(),
+		Gas:      hexutil.Uint64(tx.Gas()),
+		GasPrice: (*hexutil.Big)(tx.GasPrice()),
+		Value:    (*hexutil.Big)(tx.Value()),
+		Nonce:    hexutil.Uint64(tx.Nonce()),
+		Data:     tx.Data(),
+		ChainID:  (*hexutil.Big)(chainID),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	var res signTransactionResult
+	err := s.client.CallContext(ctx, &res, signTransactionMethod, args)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer failed signing transaction: %w", err)
+	}
+
+	signedTx := new(types.Transaction)
+	err = signedTx.UnmarshalBinary(res.Raw)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer returned invalid transaction: %w", err)
+	}
+	err = s.verifySignedTx(tx, signedTx, chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	return signedTx, nil
+}
+
+func (s *RemoteSigner) verifySignedTx(tx, signedTx *types.Transaction, chainID *big.Int) error {
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), signedTx)
+	if err != nil {
+		return fmt.Errorf("failed recovering remotely signed transaction sender: %w", err)
+	}
+	if sender != s.address {
+		return fmt.Errorf("remotely signed transaction sender %s does not match %s", sender.Hex(), s.address.Hex())
+	}
+
+	if signedTx.Nonce() != tx.Nonce() ||
+		signedTx.Gas() != tx.Gas() ||
+		signedTx.GasPrice().Cmp(tx.GasPrice()) != 0 ||
+		signedTx.Value().Cmp(tx.Value()) != 0 ||
+		!bytes.Equal(signedTx.Data(), tx.Data()) ||
+		!addressEqual(signedTx.To(), tx.To()) {
+		return fmt.Errorf("remotely signed transaction %s differs from requested transaction", signedTx.Hash().Hex())
+	}
+	return nil
+}
+
+// SignHash requests the external signer to sign the EIP-191 personal message hash of the provided data
+func (s *RemoteSigner) SignHash(hash []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	var sig hexutil.Bytes
+	err := s.client.CallContext(ctx, &sig, signDataMethod, textPlainMimeType, s.address, hexutil.Encode(hash))
+	if err != nil {
+		return nil, fmt.Errorf("remote signer failed signing data: %w", err)
+	}
+	if len(sig) != 65 {
+		return nil, fmt.Errorf("remote signer returned signature of invalid length %d", len(sig))
+	}
+
+	recoverSig := common.CopyBytes(sig)
+	recoverSig[64] -= 27
+	pub, err := crypto.SigToPub(accounts.TextHash(hash), recoverSig)
+	if err != nil {
+		return nil, fmt.Errorf("failed recovering remote signature signer: %w", err)
+	}
+	if crypto.PubkeyToAddress(*pub) != s.address {
+		return nil, fmt.Errorf("remote signature signer does not match %s", s.address.Hex())
+	}
+
+	return sig, nil
+}
+
+func addressEqual(a, b *common.Address) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,181 @@
+package evmsigner
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/mpetrun5/diplomski-projekt/crypto/secp256k1"
+)
+
+// clefStandIn implements the Clef account API methods used by RemoteSigner. It signs
+// with its keypair regardless of the requested account so that RemoteSigner verification
+// can be tested, and tamper modifies transactions before they are signed.
+type clefStandIn struct {
+	kp     *secp256k1.Keypair
+	tamper func(tx *types.Transaction) *types.Transaction
+}
+
+func (c *clefStandIn) SignTransaction(args signTransactionArgs) (*signTransactionResult, error) {
+	if args.ChainID == nil {
+		return nil, errors.New("chain ID missing")
+	}
+
+	tx := types.NewTx(&types.LegacyTx{
+		Nonce:    uint64(args.Nonce),
+		GasPrice: args.GasPrice.ToInt(),
+		Gas:      uint64(args.Gas),
+		To:       args.To,
+		Value:    args.Value.ToInt(),
+		Data:     args.Data,
+	})
+	if c.tamper != nil {
+		tx = c.tamper(tx)
+	}
+	signedTx, err := NewLocalSigner(c.kp).SignTx(tx, args.ChainID.ToInt())
+	if err != nil {
+		return nil, err
+	}
+	raw, err := signedTx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return &signTransactionResult{Raw: raw}, nil
+}
+
+func (c *clefStandIn) SignData(contentType string, address common.Address, data hexutil.Bytes) (hexutil.Bytes, error) {
+	if contentType != textPlainMimeType {
+		return nil, errors.New("unsupported content type")
+	}
+	return NewLocalSigner(c.kp).SignHash(data)
+}
+
+func newTestRemoteSigner(t *testing.T, standIn *clefStandIn, address common.Address) *RemoteSigner {
+	server := rpc.NewServer()
+	err := server.RegisterName("account", standIn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := rpc.DialInProc(server)
+	t.Cleanup(func() {
+		client.Close()
+		server.Stop()
+	})
+	return NewRemoteSigner(client, address)
+}
+
+func newTestKeypair(t *testing.T) *secp256k1.Keypair {
+	kp, err := secp256k1.GenerateKeypair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return kp
+}
+
+func newTestTx() *types.Transaction {
+	to := common.HexToAddress("0x62877dDCd49aD22f5eDfc6ac108e9a4b5D2bD88B")
+	return types.NewTx(&types.LegacyTx{
+		Nonce:    7,
+		GasPrice: big.NewInt(1000),
+		Gas:      21000,
+		To:       &to,
+		Value:    big.NewInt(5),
+		Data:     []byte{1, 2, 3},
+	})
+}
+
+func TestRemoteSigner_SignTxMatchesLocalSigner(t *testing.T) {
+	kp := newTestKeypair(t)
+	signer := newTestRemoteSigner(t, &clefStandIn{kp: kp}, kp.CommonAddress())
+	chainID := big.NewInt(5)
+
+	signedTx, err := signer.SignTx(newTestTx(), chainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	localTx, err := NewLocalSigner(kp).SignTx(newTestTx(), chainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signedTx.Hash() != localTx.Hash() {
+		t.Fatalf("remotely signed tx %s differs from locally signed tx %s", signedTx.Hash().Hex(), localTx.Hash().Hex())
+	}
+}
+
+func TestRemoteSigner_SignTxRejectsNilChainID(t *testing.T) {
+	kp := newTestKeypair(t)
+	signer := newTestRemoteSigner(t, &clefStandIn{kp: kp}, kp.CommonAddress())
+
+	_, err := signer.SignTx(newTestTx(), nil)
+	if err == nil {
+		t.Fatal("expected error for nil chain ID")
+	}
+}
+
+func TestRemoteSigner_SignTxRejectsModifiedTx(t *testing.T) {
+	kp := newTestKeypair(t)
+	standIn := &clefStandIn{
+		kp: kp,
+		tamper: func(tx *types.Transaction) *types.Transaction {
+			return types.NewTx(&types.LegacyTx{
+				Nonce:    tx.Nonce(),
+				GasPrice: tx.GasPrice(),
+				Gas:      tx.Gas(),
+				To:       tx.To(),
+				Value:    big.NewInt(1000000),
+				Data:     tx.Data(),
+			})
+		},
+	}
+	signer := newTestRemoteSigner(t, standIn, kp.CommonAddress())
+
+	_, err := signer.SignTx(newTestTx(), big.NewInt(5))
+	if err == nil || !strings.Contains(err.Error(), "differs from requested transaction") {
+		t.Fatalf("expected modified transaction error, got %v", err)
+	}
+}
+
+func TestRemoteSigner_SignTxRejectsUnexpectedSender(t *testing.T) {
+	kp := newTestKeypair(t)
+	other := newTestKeypair(t)
+	// the stand-in signs with a different key than the account the relayer expects
+	signer := newTestRemoteSigner(t, &clefStandIn{kp: other}, kp.CommonAddress())
+
+	_, err := signer.SignTx(newTestTx(), big.NewInt(5))
+	if err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Fatalf("expected sender mismatch error, got %v", err)
+	}
+}
+
+func TestRemoteSigner_SignHashRejectsUnexpectedSigner(t *testing.T) {
+	kp := newTestKeypair(t)
+	signer := newTestRemoteSigner(t, &clefStandIn{kp: newTestKeypair(t)}, kp.CommonAddress())
+
+	_, err := signer.SignHash(common.HexToHash("0x1234").Bytes())
+	if err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Fatalf("expected signer mismatch error, got %v", err)
+	}
+}
+
+func TestRemoteSigner_SignHashMatchesLocalSigner(t *testing.T) {
+	kp := newTestKeypair(t)
+	signer := newTestRemoteSigner(t, &clefStandIn{kp: kp}, kp.CommonAddress())
+	hash := common.HexToHash("0x1234").Bytes()
+
+	sig, err := signer.SignHash(hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	localSig, err := NewLocalSigner(kp).SignHash(hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hexutil.Encode(sig) != hexutil.Encode(localSig) {
+		t.Fatalf("remote signature %s differs from local signature %s", hexutil.Encode(sig), hexutil.Encode(localSig))
+	}
+}
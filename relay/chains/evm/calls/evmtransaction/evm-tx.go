@@ -1,27 +1,20 @@
 package evmtransaction
 
 import (
-	"crypto/ecdsa"
 	"math/big"
 
 	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/evmclient"
 
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
 )
 
 type TX struct {
 	tx *types.Transaction
 }
 
-func (a *TX) RawWithSignature(key *ecdsa.PrivateKey, domainID *big.Int) ([]byte, error) {
-	opts, err := bind.NewKeyedTransactorWithChainID(key, domainID)
-	if err != nil {
-		return nil, err
-	}
-	tx, err := opts.Signer(crypto.PubkeyToAddress(key.PublicKey), a.tx)
+func (a *TX) RawWithSignature(signer evmclient.Signer, domainID *big.Int) ([]byte, error) {
+	tx, err := signer.SignTx(a.tx, domainID)
 	if err != nil {
 		return nil, err
 	}
@@ -6,6 +6,9 @@ package evm
 import (
 	"fmt"
 	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/mpetrun5/diplomski-projekt/config/chain"
 	"github.com/mpetrun5/diplomski-projekt/relayer/message"
@@ -15,21 +18,47 @@ import (
 
 type EventListener interface {
 	ListenToEvents(startBlock *big.Int, domainID uint8, blockstore *store.BlockStore, stopChn <-chan struct{}, errChn chan<- error) <-chan *message.Message
+	Progress() (lastProcessedBlock *big.Int, lastProgress time.Time)
 }
 
 type ProposalVoter interface {
 	VoteProposal(message *message.Message) error
 }
 
+type ChainClient interface {
+	LatestBlock() (*big.Int, error)
+	RelayerAddress() common.Address
+}
+
+type BridgeContract interface {
+	IsRelayer(address common.Address) (bool, error)
+}
+
 type EVMChain struct {
-	listener   EventListener
-	writer     ProposalVoter
-	blockstore *store.BlockStore
-	config     *chain.EVMConfig
+	listener       EventListener
+	writer         ProposalVoter
+	blockstore     *store.BlockStore
+	config         *chain.EVMConfig
+	client         ChainClient
+	bridgeContract BridgeContract
 }
 
-func NewEVMChain(listener EventListener, writer ProposalVoter, blockstore *store.BlockStore, config *chain.EVMConfig) *EVMChain {
-	return &EVMChain{listener: listener, writer: writer, blockstore: blockstore, config: config}
+func NewEVMChain(
+	listener EventListener,
+	writer ProposalVoter,
+	blockstore *store.BlockStore,
+	config *chain.EVMConfig,
+	client ChainClient,
+	bridgeContract BridgeContract,
+) *EVMChain {
+	return &EVMChain{
+		listener:       listener,
+		writer:         writer,
+		blockstore:     blockstore,
+		config:         config,
+		client:         client,
+		bridgeContract: bridgeContract,
+	}
 }
 
 func (c *EVMChain) PollEvents(stop <-chan struct{}, sysErr chan<- error, eventsChan chan *message.Message) {
@@ -42,18 +71,19 @@ func (c *EVMChain) PollEvents(stop <-chan struct{}, sysErr chan<- error, eventsC
 		c.config.GeneralChainConfig.FreshStart,
 	)
 	if err != nil {
-		sysErr <- fmt.Errorf("error %w on getting last stored block", err)
+		select {
+		case sysErr <- fmt.Errorf("error %w on getting last stored block", err):
+		case <-stop:
+		}
 		return
 	}
 
+	// listener closes the events channel once it stops, after which it is safe to close the blockstore
 	ech := c.listener.ListenToEvents(startBlock, *c.config.GeneralChainConfig.Id, c.blockstore, stop, sysErr)
-	for {
+	for newEvent := range ech {
 		select {
+		case eventsChan <- newEvent:
 		case <-stop:
-			return
-		case newEvent := <-ech:
-			eventsChan <- newEvent
-			continue
 		}
 	}
 }
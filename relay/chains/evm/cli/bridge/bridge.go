@@ -24,4 +24,7 @@ var BridgeCmd = &cobra.Command{
 
 func init() {
 	BridgeCmd.AddCommand(registerResourceCmd)
+	BridgeCmd.AddCommand(feeCmd)
+	BridgeCmd.AddCommand(setFeeCmd)
+	BridgeCmd.AddCommand(withdrawFeesCmd)
 }
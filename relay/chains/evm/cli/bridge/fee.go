@@ -0,0 +1,64 @@
+package bridge
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/contracts/bridge"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/cli/flags"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/cli/initialize"
+	"github.com/spf13/cobra"
+)
+
+var feeCmd = &cobra.Command{
+	Use:   "fee",
+	Short: "Show the deposit fee",
+	Long:  "Show the fee, in wei, that has to be paid with every deposit",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := initialize.InitializeReadOnlyClient(url)
+		if err != nil {
+			return err
+		}
+		return FeeCmd(cmd, args, bridge.NewBridgeContract(c, BridgeAddr, nil))
+	},
+	Args: func(cmd *cobra.Command, args []string) error {
+		err := ValidateFeeFlags(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		ProcessFeeFlags(cmd, args)
+		return nil
+	},
+}
+
+func BindFeeFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&Bridge, "bridge", "", "Bridge contract address")
+	flags.MarkFlagsAsRequired(cmd, "bridge")
+}
+
+func init() {
+	BindFeeFlags(feeCmd)
+	flags.MarkAsReadOnly(feeCmd)
+}
+
+func ValidateFeeFlags(cmd *cobra.Command, args []string) error {
+	if !common.IsHexAddress(Bridge) {
+		return fmt.Errorf("invalid bridge address %s", Bridge)
+	}
+	return nil
+}
+
+func ProcessFeeFlags(cmd *cobra.Command, args []string) {
+	BridgeAddr = common.HexToAddress(Bridge)
+}
+
+func FeeCmd(cmd *cobra.Command, args []string, contract *bridge.BridgeContract) error {
+	fee, err := contract.Fee()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Deposit fee: %s wei\n", fee.String())
+	return nil
+}
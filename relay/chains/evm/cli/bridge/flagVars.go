@@ -13,6 +13,9 @@ var (
 	Handler    string
 	ResourceID string
 	Target     string
+	Fee        string
+	Recipients string
+	Amounts    string
 )
 
 //processed flag vars
@@ -21,6 +24,9 @@ var (
 	ResourceIdBytesArr [32]byte
 	HandlerAddr        common.Address
 	TargetContractAddr common.Address
+	FeeAmount          *big.Int
+	RecipientAddrs     []common.Address
+	RealAmounts        []*big.Int
 )
 
 // global flags
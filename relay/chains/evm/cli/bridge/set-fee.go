@@ -0,0 +1,81 @@
+package bridge
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/contracts/bridge"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/evmtransaction"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/transactor"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/cli/flags"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/cli/initialize"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var setFeeCmd = &cobra.Command{
+	Use:   "set-fee",
+	Short: "Set the deposit fee",
+	Long:  "Set the fee that has to be paid with every deposit. Requires the bridge admin role",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := initialize.InitializeClient(url, senderKeyPair)
+		if err != nil {
+			return err
+		}
+		t, err := initialize.InitializeTransactor(gasPrice, evmtransaction.NewTransaction, c)
+		if err != nil {
+			return err
+		}
+		return SetFeeCmd(cmd, args, bridge.NewBridgeContract(c, BridgeAddr, t))
+	},
+	Args: func(cmd *cobra.Command, args []string) error {
+		err := ValidateSetFeeFlags(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		err = ProcessSetFeeFlags(cmd, args)
+		return err
+	},
+}
+
+func BindSetFeeFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&Bridge, "bridge", "", "Bridge contract address")
+	cmd.Flags().StringVar(&Fee, "fee", "", "New deposit fee in ether")
+	flags.MarkFlagsAsRequired(cmd, "bridge", "fee")
+}
+
+func init() {
+	BindSetFeeFlags(setFeeCmd)
+}
+
+func ValidateSetFeeFlags(cmd *cobra.Command, args []string) error {
+	if !common.IsHexAddress(Bridge) {
+		return fmt.Errorf("invalid bridge address %s", Bridge)
+	}
+	return nil
+}
+
+func ProcessSetFeeFlags(cmd *cobra.Command, args []string) error {
+	var err error
+	BridgeAddr = common.HexToAddress(Bridge)
+	FeeAmount, err = etherToWei(Fee)
+	if err != nil {
+		return fmt.Errorf("invalid fee %s: %w", Fee, err)
+	}
+	if FeeAmount.Sign() == -1 {
+		return fmt.Errorf("fee can not be negative, got %s", Fee)
+	}
+	return nil
+}
+
+func SetFeeCmd(cmd *cobra.Command, args []string, contract *bridge.BridgeContract) error {
+	h, err := contract.AdminChangeFee(FeeAmount, transactor.TransactOptions{GasLimit: gasLimit})
+	if err != nil {
+		log.Error().Err(err).Msg("set fee error")
+		return err
+	}
+
+	fmt.Printf("Fee set to %s wei with hash: %s\n", FeeAmount.String(), h.Hex())
+	return nil
+}
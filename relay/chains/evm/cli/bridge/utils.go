@@ -0,0 +1,14 @@
+package bridge
+
+import (
+	"math/big"
+
+	callsUtil "github.com/mpetrun5/diplomski-projekt/chains/evm/calls"
+)
+
+// etherDecimals are the decimals of the native currency in which bridge fees are paid
+const etherDecimals = 18
+
+func etherToWei(amount string) (*big.Int, error) {
+	return callsUtil.UserAmountToWei(amount, big.NewInt(etherDecimals))
+}
@@ -0,0 +1,102 @@
+package bridge
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/contracts/bridge"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/evmtransaction"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/transactor"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/cli/flags"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/cli/initialize"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var withdrawFeesCmd = &cobra.Command{
+	Use:   "withdraw-fees",
+	Short: "Withdraw collected deposit fees",
+	Long:  "Withdraw collected deposit fees from the bridge to one or more accounts. Requires the bridge admin role",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := initialize.InitializeClient(url, senderKeyPair)
+		if err != nil {
+			return err
+		}
+		t, err := initialize.InitializeTransactor(gasPrice, evmtransaction.NewTransaction, c)
+		if err != nil {
+			return err
+		}
+		return WithdrawFeesCmd(cmd, args, bridge.NewBridgeContract(c, BridgeAddr, t))
+	},
+	Args: func(cmd *cobra.Command, args []string) error {
+		err := ValidateWithdrawFeesFlags(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		err = ProcessWithdrawFeesFlags(cmd, args)
+		return err
+	},
+}
+
+func BindWithdrawFeesFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&Bridge, "bridge", "", "Bridge contract address")
+	cmd.Flags().StringVar(&Recipients, "recipients", "", "Comma separated addresses receiving the fees")
+	cmd.Flags().StringVar(&Amounts, "amounts", "", "Comma separated amounts in ether, one per recipient")
+	flags.MarkFlagsAsRequired(cmd, "bridge", "recipients", "amounts")
+}
+
+func init() {
+	BindWithdrawFeesFlags(withdrawFeesCmd)
+}
+
+func ValidateWithdrawFeesFlags(cmd *cobra.Command, args []string) error {
+	if !common.IsHexAddress(Bridge) {
+		return fmt.Errorf("invalid bridge address %s", Bridge)
+	}
+	for _, r := range strings.Split(Recipients, ",") {
+		if !common.IsHexAddress(strings.TrimSpace(r)) {
+			return fmt.Errorf("invalid recipient address %s", r)
+		}
+	}
+	return nil
+}
+
+func ProcessWithdrawFeesFlags(cmd *cobra.Command, args []string) error {
+	BridgeAddr = common.HexToAddress(Bridge)
+
+	RecipientAddrs = []common.Address{}
+	for _, r := range strings.Split(Recipients, ",") {
+		RecipientAddrs = append(RecipientAddrs, common.HexToAddress(strings.TrimSpace(r)))
+	}
+
+	RealAmounts = []*big.Int{}
+	for _, a := range strings.Split(Amounts, ",") {
+		amount, err := etherToWei(strings.TrimSpace(a))
+		if err != nil {
+			return fmt.Errorf("invalid amount %s: %w", a, err)
+		}
+		if amount.Sign() != 1 {
+			return fmt.Errorf("amount has to be positive, got %s", a)
+		}
+		RealAmounts = append(RealAmounts, amount)
+	}
+
+	if len(RecipientAddrs) != len(RealAmounts) {
+		return fmt.Errorf("got %d recipients and %d amounts", len(RecipientAddrs), len(RealAmounts))
+	}
+	return nil
+}
+
+func WithdrawFeesCmd(cmd *cobra.Command, args []string, contract *bridge.BridgeContract) error {
+	h, err := contract.TransferFunds(RecipientAddrs, RealAmounts, transactor.TransactOptions{GasLimit: gasLimit})
+	if err != nil {
+		log.Error().Err(err).Msg("withdraw fees error")
+		return err
+	}
+
+	fmt.Printf("Fees withdrawn to %d accounts with hash: %s\n", len(RecipientAddrs), h.Hex())
+	return nil
+}
@@ -2,7 +2,11 @@ package cli
 
 import (
 	"github.com/mpetrun5/diplomski-projekt/chains/evm/cli/bridge"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/cli/erc1155"
 	"github.com/mpetrun5/diplomski-projekt/chains/evm/cli/erc20"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/cli/erc721"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/cli/generic"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/cli/native"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -58,4 +62,16 @@ func init() {
 
 	// erc20
 	EvmRootCLI.AddCommand(erc20.ERC20Cmd)
+
+	// erc721
+	EvmRootCLI.AddCommand(erc721.ERC721Cmd)
+
+	// erc1155
+	EvmRootCLI.AddCommand(erc1155.ERC1155Cmd)
+
+	// generic
+	EvmRootCLI.AddCommand(generic.GenericCmd)
+
+	// native
+	EvmRootCLI.AddCommand(native.NativeCmd)
 }
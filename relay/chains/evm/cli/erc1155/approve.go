@@ -0,0 +1,82 @@
+package erc1155
+
+import (
+	"fmt"
+
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/contracts/erc1155"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/evmtransaction"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/transactor"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/cli/initialize"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/cli/flags"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var approveCmd = &cobra.Command{
+	Use:   "approve",
+	Short: "Approve an operator for all ERC1155 tokens",
+	Long:  "Approve an operator, usually the ERC1155 handler, to transfer all ERC1155 tokens of the sender",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := initialize.InitializeClient(url, senderKeyPair)
+		if err != nil {
+			return err
+		}
+		t, err := initialize.InitializeTransactor(gasPrice, evmtransaction.NewTransaction, c)
+		if err != nil {
+			return err
+		}
+		return ApproveCmd(cmd, args, erc1155.NewERC1155Contract(c, Erc1155Addr, t))
+	},
+	Args: func(cmd *cobra.Command, args []string) error {
+		err := ValidateApproveFlags(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		ProcessApproveFlags(cmd, args)
+		return nil
+	},
+}
+
+func BindApproveFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&Erc1155Address, "contract", "", "ERC1155 contract address")
+	cmd.Flags().StringVar(&Operator, "operator", "", "Address of the approved operator")
+	cmd.Flags().BoolVar(&Revoke, "revoke", false, "Revoke the operator approval instead of granting it")
+	flags.MarkFlagsAsRequired(cmd, "contract", "operator")
+}
+
+func init() {
+	BindApproveFlags(approveCmd)
+}
+
+func ValidateApproveFlags(cmd *cobra.Command, args []string) error {
+	if !common.IsHexAddress(Erc1155Address) {
+		return fmt.Errorf("invalid ERC1155 contract address %s", Erc1155Address)
+	}
+	if !common.IsHexAddress(Operator) {
+		return fmt.Errorf("invalid operator address %s", Operator)
+	}
+	return nil
+}
+
+func ProcessApproveFlags(cmd *cobra.Command, args []string) {
+	Erc1155Addr = common.HexToAddress(Erc1155Address)
+	OperatorAddress = common.HexToAddress(Operator)
+}
+
+func ApproveCmd(cmd *cobra.Command, args []string, contract *erc1155.ERC1155Contract) error {
+	_, err := contract.SetApprovalForAll(OperatorAddress, !Revoke, transactor.TransactOptions{GasLimit: gasLimit})
+	if err != nil {
+		log.Error().Err(err).Msg("erc1155 approve error")
+		return err
+	}
+
+	if Revoke {
+		fmt.Printf("%s operator approval revoked\n", OperatorAddress.String())
+		return nil
+	}
+	fmt.Printf("%s operator approved to transfer all tokens\n", OperatorAddress.String())
+	return nil
+}
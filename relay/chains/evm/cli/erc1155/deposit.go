@@ -0,0 +1,111 @@
+package erc1155
+
+import (
+	"fmt"
+
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/contracts/bridge"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/evmtransaction"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/transactor"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/cli/initialize"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/cli/flags"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var depositCmd = &cobra.Command{
+	Use:   "deposit",
+	Short: "Deposit a batch of ERC1155 tokens",
+	Long:  "Deposit a batch of ERC1155 tokens, where each token ID is transferred in the amount at the same position",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := initialize.InitializeClient(url, senderKeyPair)
+		if err != nil {
+			return err
+		}
+		t, err := initialize.InitializeTransactor(gasPrice, evmtransaction.NewTransaction, c)
+		if err != nil {
+			return err
+		}
+		return DepositCmd(cmd, args, bridge.NewBridgeContract(c, BridgeAddr, t))
+	},
+	Args: func(cmd *cobra.Command, args []string) error {
+		err := ValidateDepositFlags(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		err = ProcessDepositFlags(cmd, args)
+		return err
+	},
+}
+
+func BindDepositFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&Recipient, "recipient", "", "Address of recipient")
+	cmd.Flags().StringVar(&Bridge, "bridge", "", "Address of bridge contract")
+	cmd.Flags().Uint8Var(&DomainID, "domain", 0, "Destination domain ID")
+	cmd.Flags().StringVar(&ResourceID, "resource", "", "Resource ID for transfer")
+	cmd.Flags().StringVar(&TokenIDs, "tokens", "", "Comma separated ERC1155 token IDs")
+	cmd.Flags().StringVar(&Amounts, "amounts", "", "Comma separated amounts, one per token ID")
+	cmd.Flags().StringVar(&Data, "data", "", "Hex encoded data passed to the recipient on transfer")
+	flags.MarkFlagsAsRequired(cmd, "recipient", "bridge", "domain", "resource", "tokens", "amounts")
+}
+
+func init() {
+	BindDepositFlags(depositCmd)
+}
+
+func ValidateDepositFlags(cmd *cobra.Command, args []string) error {
+	if !common.IsHexAddress(Recipient) {
+		return fmt.Errorf("invalid recipient address %s", Recipient)
+	}
+	if !common.IsHexAddress(Bridge) {
+		return fmt.Errorf("invalid bridge address %s", Bridge)
+	}
+	return nil
+}
+
+func ProcessDepositFlags(cmd *cobra.Command, args []string) error {
+	var err error
+
+	RecipientAddress = common.HexToAddress(Recipient)
+	BridgeAddr = common.HexToAddress(Bridge)
+	TokenIDsBigInt, err = processBigIntList(TokenIDs)
+	if err != nil {
+		return fmt.Errorf("invalid token ids: %w", err)
+	}
+	AmountsBigInt, err = processBigIntList(Amounts)
+	if err != nil {
+		return fmt.Errorf("invalid amounts: %w", err)
+	}
+	if len(TokenIDsBigInt) != len(AmountsBigInt) {
+		return fmt.Errorf("got %d token ids and %d amounts", len(TokenIDsBigInt), len(AmountsBigInt))
+	}
+	TransferData = []byte{}
+	if Data != "" {
+		TransferData, err = hexutil.Decode(Data)
+		if err != nil {
+			return fmt.Errorf("invalid transfer data %s: %w", Data, err)
+		}
+	}
+	ResourceIdBytesArr, err = flags.ProcessResourceID(ResourceID)
+	return err
+}
+
+func DepositCmd(cmd *cobra.Command, args []string, contract *bridge.BridgeContract) error {
+	hash, err := contract.Erc1155Deposit(
+		TokenIDsBigInt, AmountsBigInt, RecipientAddress, TransferData, ResourceIdBytesArr,
+		DomainID, transactor.TransactOptions{GasLimit: gasLimit},
+	)
+	if err != nil {
+		log.Error().Err(err).Msg("erc1155 deposit error")
+		return err
+	}
+
+	fmt.Printf(
+		"%d token types were transferred to %s from %s with hash %s\n",
+		len(TokenIDsBigInt), RecipientAddress.Hex(), senderKeyPair.CommonAddress().String(), hash.Hex(),
+	)
+	return nil
+}
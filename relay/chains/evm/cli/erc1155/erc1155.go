@@ -0,0 +1,27 @@
+package erc1155
+
+import (
+	"fmt"
+
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/cli/flags"
+	"github.com/spf13/cobra"
+)
+
+var ERC1155Cmd = &cobra.Command{
+	Use:   "erc1155",
+	Short: "Set of commands for interacting with an ERC1155 contract",
+	Long:  "Set of commands for interacting with an ERC1155 contract",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		var err error
+		url, gasLimit, gasPrice, senderKeyPair, err = flags.GlobalFlagValues(cmd)
+		if err != nil {
+			return fmt.Errorf("could not get global flags: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	ERC1155Cmd.AddCommand(approveCmd)
+	ERC1155Cmd.AddCommand(depositCmd)
+}
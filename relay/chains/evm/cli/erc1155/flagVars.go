@@ -0,0 +1,43 @@
+package erc1155
+
+import (
+	"math/big"
+
+	"github.com/mpetrun5/diplomski-projekt/crypto/secp256k1"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//flag vars
+var (
+	Erc1155Address string
+	Operator       string
+	Revoke         bool
+	Recipient      string
+	Bridge         string
+	DomainID       uint8
+	ResourceID     string
+	TokenIDs       string
+	Amounts        string
+	Data           string
+)
+
+//processed flag vars
+var (
+	Erc1155Addr        common.Address
+	OperatorAddress    common.Address
+	RecipientAddress   common.Address
+	BridgeAddr         common.Address
+	TokenIDsBigInt     []*big.Int
+	AmountsBigInt      []*big.Int
+	TransferData       []byte
+	ResourceIdBytesArr [32]byte
+)
+
+// global flags
+var (
+	url           string
+	gasLimit      uint64
+	gasPrice      *big.Int
+	senderKeyPair *secp256k1.Keypair
+)
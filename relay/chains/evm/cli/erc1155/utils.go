@@ -0,0 +1,21 @@
+package erc1155
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// processBigIntList parses a comma separated list of non-negative decimal integers
+func processBigIntList(list string) ([]*big.Int, error) {
+	values := []*big.Int{}
+	for _, v := range strings.Split(list, ",") {
+		v = strings.TrimSpace(v)
+		n, ok := new(big.Int).SetString(v, 10)
+		if !ok || n.Sign() == -1 {
+			return nil, fmt.Errorf("invalid value %s", v)
+		}
+		values = append(values, n)
+	}
+	return values, nil
+}
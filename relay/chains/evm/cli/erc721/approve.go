@@ -0,0 +1,82 @@
+package erc721
+
+import (
+	"fmt"
+
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/contracts/erc721"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/evmtransaction"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/transactor"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/cli/initialize"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/cli/flags"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var approveCmd = &cobra.Command{
+	Use:   "approve",
+	Short: "Approve an ERC721 token",
+	Long:  "Approve an ERC721 token to be transferred by the recipient, usually the ERC721 handler",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := initialize.InitializeClient(url, senderKeyPair)
+		if err != nil {
+			return err
+		}
+		t, err := initialize.InitializeTransactor(gasPrice, evmtransaction.NewTransaction, c)
+		if err != nil {
+			return err
+		}
+		return ApproveCmd(cmd, args, erc721.NewERC721Contract(c, Erc721Addr, t))
+	},
+	Args: func(cmd *cobra.Command, args []string) error {
+		err := ValidateApproveFlags(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		err = ProcessApproveFlags(cmd, args)
+		return err
+	},
+}
+
+func BindApproveFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&Erc721Address, "contract", "", "ERC721 contract address")
+	cmd.Flags().StringVar(&Recipient, "recipient", "", "Address of the approved account")
+	cmd.Flags().StringVar(&TokenID, "token", "", "ERC721 token ID")
+	flags.MarkFlagsAsRequired(cmd, "contract", "recipient", "token")
+}
+
+func init() {
+	BindApproveFlags(approveCmd)
+}
+
+func ValidateApproveFlags(cmd *cobra.Command, args []string) error {
+	if !common.IsHexAddress(Erc721Address) {
+		return fmt.Errorf("invalid ERC721 contract address %s", Erc721Address)
+	}
+	if !common.IsHexAddress(Recipient) {
+		return fmt.Errorf("invalid recipient address %s", Recipient)
+	}
+	return nil
+}
+
+func ProcessApproveFlags(cmd *cobra.Command, args []string) error {
+	var err error
+
+	Erc721Addr = common.HexToAddress(Erc721Address)
+	RecipientAddress = common.HexToAddress(Recipient)
+	TokenIDBigInt, err = processTokenID(TokenID)
+	return err
+}
+
+func ApproveCmd(cmd *cobra.Command, args []string, contract *erc721.ERC721Contract) error {
+	_, err := contract.Approve(TokenIDBigInt, RecipientAddress, transactor.TransactOptions{GasLimit: gasLimit})
+	if err != nil {
+		log.Error().Err(err).Msg("erc721 approve error")
+		return err
+	}
+
+	fmt.Printf("%s account approved to transfer token %s\n", RecipientAddress.String(), TokenIDBigInt.String())
+	return nil
+}
@@ -0,0 +1,95 @@
+package erc721
+
+import (
+	"fmt"
+
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/contracts/bridge"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/evmtransaction"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/transactor"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/cli/initialize"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/cli/flags"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var depositCmd = &cobra.Command{
+	Use:   "deposit",
+	Short: "Deposit an ERC721 token",
+	Long:  "Deposit an ERC721 token",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := initialize.InitializeClient(url, senderKeyPair)
+		if err != nil {
+			return err
+		}
+		t, err := initialize.InitializeTransactor(gasPrice, evmtransaction.NewTransaction, c)
+		if err != nil {
+			return err
+		}
+		return DepositCmd(cmd, args, bridge.NewBridgeContract(c, BridgeAddr, t))
+	},
+	Args: func(cmd *cobra.Command, args []string) error {
+		err := ValidateDepositFlags(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		err = ProcessDepositFlags(cmd, args)
+		return err
+	},
+}
+
+func BindDepositFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&Recipient, "recipient", "", "Address of recipient")
+	cmd.Flags().StringVar(&Bridge, "bridge", "", "Address of bridge contract")
+	cmd.Flags().Uint8Var(&DomainID, "domain", 0, "Destination domain ID")
+	cmd.Flags().StringVar(&ResourceID, "resource", "", "Resource ID for transfer")
+	cmd.Flags().StringVar(&TokenID, "token", "", "ERC721 token ID")
+	cmd.Flags().StringVar(&Metadata, "metadata", "", "ERC721 token metadata")
+	flags.MarkFlagsAsRequired(cmd, "recipient", "bridge", "domain", "resource", "token")
+}
+
+func init() {
+	BindDepositFlags(depositCmd)
+}
+
+func ValidateDepositFlags(cmd *cobra.Command, args []string) error {
+	if !common.IsHexAddress(Recipient) {
+		return fmt.Errorf("invalid recipient address %s", Recipient)
+	}
+	if !common.IsHexAddress(Bridge) {
+		return fmt.Errorf("invalid bridge address %s", Bridge)
+	}
+	return nil
+}
+
+func ProcessDepositFlags(cmd *cobra.Command, args []string) error {
+	var err error
+
+	RecipientAddress = common.HexToAddress(Recipient)
+	BridgeAddr = common.HexToAddress(Bridge)
+	TokenIDBigInt, err = processTokenID(TokenID)
+	if err != nil {
+		return err
+	}
+	ResourceIdBytesArr, err = flags.ProcessResourceID(ResourceID)
+	return err
+}
+
+func DepositCmd(cmd *cobra.Command, args []string, contract *bridge.BridgeContract) error {
+	hash, err := contract.Erc721Deposit(
+		TokenIDBigInt, Metadata, RecipientAddress, ResourceIdBytesArr,
+		DomainID, transactor.TransactOptions{GasLimit: gasLimit},
+	)
+	if err != nil {
+		log.Error().Err(err).Msg("erc721 deposit error")
+		return err
+	}
+
+	fmt.Printf(
+		"%s token was transferred to %s from %s with hash %s\n",
+		TokenIDBigInt.String(), RecipientAddress.Hex(), senderKeyPair.CommonAddress().String(), hash.Hex(),
+	)
+	return nil
+}
@@ -0,0 +1,37 @@
+package erc721
+
+import (
+	"math/big"
+
+	"github.com/mpetrun5/diplomski-projekt/crypto/secp256k1"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//flag vars
+var (
+	Erc721Address string
+	Recipient     string
+	Bridge        string
+	DomainID      uint8
+	ResourceID    string
+	TokenID       string
+	Metadata      string
+)
+
+//processed flag vars
+var (
+	Erc721Addr         common.Address
+	RecipientAddress   common.Address
+	BridgeAddr         common.Address
+	TokenIDBigInt      *big.Int
+	ResourceIdBytesArr [32]byte
+)
+
+// global flags
+var (
+	url           string
+	gasLimit      uint64
+	gasPrice      *big.Int
+	senderKeyPair *secp256k1.Keypair
+)
@@ -0,0 +1,83 @@
+package erc721
+
+import (
+	"fmt"
+
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/contracts/erc721"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/evmtransaction"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/transactor"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/cli/initialize"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/cli/flags"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var mintCmd = &cobra.Command{
+	Use:   "mint",
+	Short: "Mint an ERC721 token",
+	Long:  "Mint an ERC721 token with the provided token ID and metadata",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := initialize.InitializeClient(url, senderKeyPair)
+		if err != nil {
+			return err
+		}
+		t, err := initialize.InitializeTransactor(gasPrice, evmtransaction.NewTransaction, c)
+		if err != nil {
+			return err
+		}
+		return MintCmd(cmd, args, erc721.NewERC721Contract(c, Erc721Addr, t))
+	},
+	Args: func(cmd *cobra.Command, args []string) error {
+		err := ValidateMintFlags(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		err = ProcessMintFlags(cmd, args)
+		return err
+	},
+}
+
+func BindMintFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&Erc721Address, "contract", "", "ERC721 contract address")
+	cmd.Flags().StringVar(&Recipient, "recipient", "", "Address of the token owner")
+	cmd.Flags().StringVar(&TokenID, "token", "", "ERC721 token ID")
+	cmd.Flags().StringVar(&Metadata, "metadata", "", "ERC721 token metadata")
+	flags.MarkFlagsAsRequired(cmd, "contract", "recipient", "token")
+}
+
+func init() {
+	BindMintFlags(mintCmd)
+}
+
+func ValidateMintFlags(cmd *cobra.Command, args []string) error {
+	if !common.IsHexAddress(Erc721Address) {
+		return fmt.Errorf("invalid ERC721 contract address %s", Erc721Address)
+	}
+	if !common.IsHexAddress(Recipient) {
+		return fmt.Errorf("invalid recipient address %s", Recipient)
+	}
+	return nil
+}
+
+func ProcessMintFlags(cmd *cobra.Command, args []string) error {
+	var err error
+
+	Erc721Addr = common.HexToAddress(Erc721Address)
+	RecipientAddress = common.HexToAddress(Recipient)
+	TokenIDBigInt, err = processTokenID(TokenID)
+	return err
+}
+
+func MintCmd(cmd *cobra.Command, args []string, contract *erc721.ERC721Contract) error {
+	_, err := contract.Mint(TokenIDBigInt, Metadata, RecipientAddress, transactor.TransactOptions{GasLimit: gasLimit})
+	if err != nil {
+		log.Error().Err(err).Msg("erc721 mint error")
+		return err
+	}
+
+	fmt.Printf("%s token minted to %s\n", TokenIDBigInt.String(), RecipientAddress.String())
+	return nil
+}
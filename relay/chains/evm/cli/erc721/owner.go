@@ -0,0 +1,72 @@
+package erc721
+
+import (
+	"fmt"
+
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/contracts/erc721"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/cli/initialize"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/cli/flags"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var ownerCmd = &cobra.Command{
+	Use:   "owner",
+	Short: "Get an ERC721 token owner",
+	Long:  "Get an ERC721 token owner",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := initialize.InitializeReadOnlyClient(url)
+		if err != nil {
+			return err
+		}
+		return OwnerCmd(cmd, args, erc721.NewERC721Contract(c, Erc721Addr, nil))
+	},
+	Args: func(cmd *cobra.Command, args []string) error {
+		err := ValidateOwnerFlags(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		err = ProcessOwnerFlags(cmd, args)
+		return err
+	},
+}
+
+func BindOwnerFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&Erc721Address, "contract", "", "ERC721 contract address")
+	cmd.Flags().StringVar(&TokenID, "token", "", "ERC721 token ID")
+	flags.MarkFlagsAsRequired(cmd, "contract", "token")
+}
+
+func init() {
+	BindOwnerFlags(ownerCmd)
+	flags.MarkAsReadOnly(ownerCmd)
+}
+
+func ValidateOwnerFlags(cmd *cobra.Command, args []string) error {
+	if !common.IsHexAddress(Erc721Address) {
+		return fmt.Errorf("invalid ERC721 contract address %s", Erc721Address)
+	}
+	return nil
+}
+
+func ProcessOwnerFlags(cmd *cobra.Command, args []string) error {
+	var err error
+
+	Erc721Addr = common.HexToAddress(Erc721Address)
+	TokenIDBigInt, err = processTokenID(TokenID)
+	return err
+}
+
+func OwnerCmd(cmd *cobra.Command, args []string, contract *erc721.ERC721Contract) error {
+	owner, err := contract.Owner(TokenIDBigInt)
+	if err != nil {
+		log.Error().Err(err).Msg("erc721 owner error")
+		return err
+	}
+
+	fmt.Printf("%s token owner: %v\n", TokenIDBigInt.String(), owner.String())
+	return nil
+}
@@ -0,0 +1,14 @@
+package erc721
+
+import (
+	"fmt"
+	"math/big"
+)
+
+func processTokenID(tokenID string) (*big.Int, error) {
+	id, ok := new(big.Int).SetString(tokenID, 10)
+	if !ok || id.Sign() == -1 {
+		return nil, fmt.Errorf("invalid token id %s", tokenID)
+	}
+	return id, nil
+}
@@ -2,10 +2,13 @@ package flags
 
 import (
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"math/big"
 
 	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls"
+	"github.com/mpetrun5/diplomski-projekt/keystore"
 
 	"github.com/mpetrun5/diplomski-projekt/crypto/secp256k1"
 	"github.com/spf13/cobra"
@@ -13,6 +16,9 @@ import (
 
 var DefaultGasLimit = uint64(200000)
 
+// readOnlyAnnotation marks commands that only read chain state and don't need a sender
+const readOnlyAnnotation = "readOnly"
+
 func GlobalFlagValues(cmd *cobra.Command) (string, uint64, *big.Int, *secp256k1.Keypair, error) {
 	url, err := cmd.Flags().GetString("url")
 	if err != nil {
@@ -33,6 +39,9 @@ func GlobalFlagValues(cmd *cobra.Command) (string, uint64, *big.Int, *secp256k1.
 		gasPrice = big.NewInt(0).SetUint64(gasPriceInt)
 	}
 
+	if isReadOnly(cmd) {
+		return url, gasLimitInt, gasPrice, nil, nil
+	}
 	senderKeyPair, err := defineSender(cmd)
 	if err != nil {
 		return "", DefaultGasLimit, nil, nil, err
@@ -53,7 +62,35 @@ func defineSender(cmd *cobra.Command) (*secp256k1.Keypair, error) {
 		return kp, nil
 	}
 
-	return nil, nil
+	jsonWallet, err := cmd.Flags().GetString("json-wallet")
+	if err != nil {
+		return nil, err
+	}
+	if jsonWallet != "" {
+		return keypairFromJSONWallet(cmd, jsonWallet)
+	}
+
+	return nil, errors.New("no sender provided: either --private-key or --json-wallet has to be set")
+}
+
+func keypairFromJSONWallet(cmd *cobra.Command, path string) (*secp256k1.Keypair, error) {
+	keyJSON, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading JSON wallet %s: %w", path, err)
+	}
+
+	password, err := cmd.Flags().GetString("json-wallet-password")
+	if err != nil {
+		return nil, err
+	}
+	if password == "" {
+		password, err = keystore.PromptPassword("Enter password of the JSON wallet:")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return keystore.KeypairFromJSON(keyJSON, password)
 }
 
 func ProcessResourceID(resourceID string) ([32]byte, error) {
@@ -67,6 +104,20 @@ func ProcessResourceID(resourceID string) ([32]byte, error) {
 	return calls.SliceTo32Bytes(resourceIdBytes), nil
 }
 
+// MarkAsReadOnly lets the command run without a sender, global flag values
+// of read-only commands have a nil sender keypair
+func MarkAsReadOnly(cmd *cobra.Command) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = make(map[string]string)
+	}
+	cmd.Annotations[readOnlyAnnotation] = "true"
+}
+
+func isReadOnly(cmd *cobra.Command) bool {
+	_, ok := cmd.Annotations[readOnlyAnnotation]
+	return ok
+}
+
 func MarkFlagsAsRequired(cmd *cobra.Command, flags ...string) {
 	for _, flag := range flags {
 		err := cmd.MarkFlagRequired(flag)
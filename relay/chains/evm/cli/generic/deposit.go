@@ -0,0 +1,84 @@
+package generic
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/contracts/bridge"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/evmtransaction"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/transactor"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/cli/flags"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/cli/initialize"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var depositCmd = &cobra.Command{
+	Use:   "deposit",
+	Short: "Deposit raw calldata",
+	Long:  "Deposit raw calldata that is relayed unchanged to the function registered for the resource on the destination",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := initialize.InitializeClient(url, senderKeyPair)
+		if err != nil {
+			return err
+		}
+		t, err := initialize.InitializeTransactor(gasPrice, evmtransaction.NewTransaction, c)
+		if err != nil {
+			return err
+		}
+		return DepositCmd(cmd, args, bridge.NewBridgeContract(c, BridgeAddr, t))
+	},
+	Args: func(cmd *cobra.Command, args []string) error {
+		err := ValidateDepositFlags(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		err = ProcessDepositFlags(cmd, args)
+		return err
+	},
+}
+
+func BindDepositFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&Bridge, "bridge", "", "Address of bridge contract")
+	cmd.Flags().Uint8Var(&DomainID, "domain", 0, "Destination domain ID")
+	cmd.Flags().StringVar(&ResourceID, "resource", "", "Resource ID for transfer")
+	cmd.Flags().StringVar(&Data, "data", "", "Hex encoded calldata passed to the destination function")
+	flags.MarkFlagsAsRequired(cmd, "bridge", "domain", "resource", "data")
+}
+
+func init() {
+	BindDepositFlags(depositCmd)
+}
+
+func ValidateDepositFlags(cmd *cobra.Command, args []string) error {
+	if !common.IsHexAddress(Bridge) {
+		return fmt.Errorf("invalid bridge address %s", Bridge)
+	}
+	return nil
+}
+
+func ProcessDepositFlags(cmd *cobra.Command, args []string) error {
+	var err error
+	BridgeAddr = common.HexToAddress(Bridge)
+	Metadata, err = hexutil.Decode(Data)
+	if err != nil {
+		return fmt.Errorf("invalid calldata %s: %w", Data, err)
+	}
+	ResourceIdBytesArr, err = flags.ProcessResourceID(ResourceID)
+	return err
+}
+
+func DepositCmd(cmd *cobra.Command, args []string, contract *bridge.BridgeContract) error {
+	hash, err := contract.GenericDeposit(
+		Metadata, ResourceIdBytesArr, DomainID, transactor.TransactOptions{GasLimit: gasLimit},
+	)
+	if err != nil {
+		log.Error().Err(err).Msg("generic deposit error")
+		return err
+	}
+
+	fmt.Printf("Calldata deposited from %s with hash %s\n", senderKeyPair.CommonAddress().String(), hash.Hex())
+	return nil
+}
@@ -0,0 +1,40 @@
+package generic
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mpetrun5/diplomski-projekt/crypto/secp256k1"
+)
+
+//flag vars
+var (
+	Bridge          string
+	Handler         string
+	ResourceID      string
+	Target          string
+	Deposit         string
+	DepositerOffset uint64
+	Execute         string
+	DomainID        uint8
+	Data            string
+)
+
+//processed flag vars
+var (
+	BridgeAddr         common.Address
+	HandlerAddr        common.Address
+	TargetContractAddr common.Address
+	ResourceIdBytesArr [32]byte
+	DepositSigBytes    [4]byte
+	ExecuteSigBytes    [4]byte
+	Metadata           []byte
+)
+
+// global flags
+var (
+	url           string
+	gasLimit      uint64
+	gasPrice      *big.Int
+	senderKeyPair *secp256k1.Keypair
+)
@@ -0,0 +1,27 @@
+package generic
+
+import (
+	"fmt"
+
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/cli/flags"
+	"github.com/spf13/cobra"
+)
+
+var GenericCmd = &cobra.Command{
+	Use:   "generic",
+	Short: "Set of commands for relaying arbitrary calls through a generic handler",
+	Long:  "Set of commands for relaying arbitrary calls through a generic handler",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		var err error
+		url, gasLimit, gasPrice, senderKeyPair, err = flags.GlobalFlagValues(cmd)
+		if err != nil {
+			return fmt.Errorf("could not get global flags: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	GenericCmd.AddCommand(registerCmd)
+	GenericCmd.AddCommand(depositCmd)
+}
@@ -0,0 +1,107 @@
+package generic
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/contracts/bridge"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/evmtransaction"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/transactor"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/cli/flags"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/cli/initialize"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var registerCmd = &cobra.Command{
+	Use:   "register",
+	Short: "Register a generic resource",
+	Long: "Register a target contract and its functions for a resource ID on the generic handler. " +
+		"Function called on the destination receives the relayed calldata as its arguments.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := initialize.InitializeClient(url, senderKeyPair)
+		if err != nil {
+			return err
+		}
+		t, err := initialize.InitializeTransactor(gasPrice, evmtransaction.NewTransaction, c)
+		if err != nil {
+			return err
+		}
+		return RegisterCmd(cmd, args, bridge.NewBridgeContract(c, BridgeAddr, t))
+	},
+	Args: func(cmd *cobra.Command, args []string) error {
+		err := ValidateRegisterFlags(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		err = ProcessRegisterFlags(cmd, args)
+		return err
+	},
+}
+
+func BindRegisterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&Handler, "handler", "", "Generic handler contract address")
+	cmd.Flags().StringVar(&Bridge, "bridge", "", "Bridge contract address")
+	cmd.Flags().StringVar(&Target, "target", "", "Contract address to be called")
+	cmd.Flags().StringVar(&ResourceID, "resource", "", "Resource ID to be registered")
+	cmd.Flags().StringVar(&Execute, "execute", "", "Signature or 4 byte selector of the function called when a proposal is executed, e.g. store(bytes32)")
+	cmd.Flags().StringVar(&Deposit, "deposit", "", "Signature or 4 byte selector of the function called on deposit")
+	cmd.Flags().Uint64Var(&DepositerOffset, "depositer-offset", 0, "Offset of the depositer address in deposit calldata")
+	flags.MarkFlagsAsRequired(cmd, "handler", "bridge", "target", "resource", "execute")
+}
+
+func init() {
+	BindRegisterFlags(registerCmd)
+}
+
+func ValidateRegisterFlags(cmd *cobra.Command, args []string) error {
+	if !common.IsHexAddress(Handler) {
+		return fmt.Errorf("invalid handler address %s", Handler)
+	}
+	if !common.IsHexAddress(Target) {
+		return fmt.Errorf("invalid target address %s", Target)
+	}
+	if !common.IsHexAddress(Bridge) {
+		return fmt.Errorf("invalid bridge address %s", Bridge)
+	}
+	return nil
+}
+
+func ProcessRegisterFlags(cmd *cobra.Command, args []string) error {
+	var err error
+	HandlerAddr = common.HexToAddress(Handler)
+	TargetContractAddr = common.HexToAddress(Target)
+	BridgeAddr = common.HexToAddress(Bridge)
+
+	ResourceIdBytesArr, err = flags.ProcessResourceID(ResourceID)
+	if err != nil {
+		return err
+	}
+	DepositSigBytes, err = processFunctionSig(Deposit)
+	if err != nil {
+		return err
+	}
+	ExecuteSigBytes, err = processFunctionSig(Execute)
+	return err
+}
+
+func RegisterCmd(cmd *cobra.Command, args []string, contract *bridge.BridgeContract) error {
+	h, err := contract.AdminSetGenericResource(
+		HandlerAddr, ResourceIdBytesArr, TargetContractAddr,
+		DepositSigBytes, new(big.Int).SetUint64(DepositerOffset), ExecuteSigBytes,
+		transactor.TransactOptions{GasLimit: gasLimit},
+	)
+	if err != nil {
+		log.Error().Err(err)
+		return err
+	}
+
+	fmt.Printf(
+		"Generic resource registered with execute function %s and hash: %s\n",
+		hexutil.Encode(ExecuteSigBytes[:]), h.Hex(),
+	)
+	return nil
+}
@@ -0,0 +1,34 @@
+package generic
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// processFunctionSig converts a function signature, such as "store(bytes32)", or a hex encoded
+// 4 byte selector into a function selector. Empty signature results in a zero selector
+// which disables the call on the handler side.
+func processFunctionSig(sig string) ([4]byte, error) {
+	var selector [4]byte
+	if sig == "" {
+		return selector, nil
+	}
+
+	if strings.HasPrefix(sig, "0x") {
+		b, err := hexutil.Decode(sig)
+		if err != nil || len(b) != 4 {
+			return selector, fmt.Errorf("invalid function selector %s", sig)
+		}
+		copy(selector[:], b)
+		return selector, nil
+	}
+
+	if !strings.Contains(sig, "(") || !strings.HasSuffix(sig, ")") {
+		return selector, fmt.Errorf("invalid function signature %s", sig)
+	}
+	copy(selector[:], crypto.Keccak256([]byte(sig))[:4])
+	return selector, nil
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls"
 	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/evmclient"
 	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/evmgaspricer"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/evmsigner"
 	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/transactor"
 	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/transactor/signAndSend"
 	"github.com/mpetrun5/diplomski-projekt/crypto/secp256k1"
@@ -15,14 +16,18 @@ func InitializeClient(
 	url string,
 	senderKeyPair *secp256k1.Keypair,
 ) (*evmclient.EVMClient, error) {
-	ethClient, err := evmclient.NewEVMClientFromParams(
-		url, senderKeyPair.PrivateKey())
+	ethClient, err := evmclient.NewEVMClientFromParams(url, evmsigner.NewLocalSigner(senderKeyPair))
 	if err != nil {
 		return nil, err
 	}
 	return ethClient, nil
 }
 
+// InitializeReadOnlyClient creates a client without a signer that can only be used for calls
+func InitializeReadOnlyClient(url string) (*evmclient.EVMClient, error) {
+	return evmclient.NewEVMClientFromParams(url, nil)
+}
+
 func InitializeTransactor(
 	gasPrice *big.Int,
 	txFabric calls.TxFabric,
@@ -0,0 +1,102 @@
+package native
+
+import (
+	"fmt"
+	"math/big"
+
+	callsUtil "github.com/mpetrun5/diplomski-projekt/chains/evm/calls"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/contracts/bridge"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/evmtransaction"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/transactor"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/cli/initialize"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/cli/flags"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+// nativeDecimals are the decimals of the native currency, deposit amount is given in ether
+const nativeDecimals = 18
+
+var depositCmd = &cobra.Command{
+	Use:   "deposit",
+	Short: "Deposit native currency",
+	Long:  "Deposit native currency, which is locked on the source chain and released or minted as a wrapped token on the destination chain",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := initialize.InitializeClient(url, senderKeyPair)
+		if err != nil {
+			return err
+		}
+		t, err := initialize.InitializeTransactor(gasPrice, evmtransaction.NewTransaction, c)
+		if err != nil {
+			return err
+		}
+		return DepositCmd(cmd, args, bridge.NewBridgeContract(c, BridgeAddr, t))
+	},
+	Args: func(cmd *cobra.Command, args []string) error {
+		err := ValidateDepositFlags(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		err = ProcessDepositFlags(cmd, args)
+		return err
+	},
+}
+
+func BindDepositFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&Recipient, "recipient", "", "Address of recipient")
+	cmd.Flags().StringVar(&Bridge, "bridge", "", "Address of bridge contract")
+	cmd.Flags().StringVar(&Amount, "amount", "", "Amount to deposit in ether")
+	cmd.Flags().Uint8Var(&DomainID, "domain", 0, "Destination domain ID")
+	cmd.Flags().StringVar(&ResourceID, "resource", "", "Resource ID for transfer")
+	flags.MarkFlagsAsRequired(cmd, "recipient", "bridge", "amount", "domain", "resource")
+}
+
+func init() {
+	BindDepositFlags(depositCmd)
+}
+
+func ValidateDepositFlags(cmd *cobra.Command, args []string) error {
+	if !common.IsHexAddress(Recipient) {
+		return fmt.Errorf("invalid recipient address %s", Recipient)
+	}
+	if !common.IsHexAddress(Bridge) {
+		return fmt.Errorf("invalid bridge address %s", Bridge)
+	}
+	return nil
+}
+
+func ProcessDepositFlags(cmd *cobra.Command, args []string) error {
+	var err error
+
+	RecipientAddress = common.HexToAddress(Recipient)
+	BridgeAddr = common.HexToAddress(Bridge)
+	RealAmount, err = callsUtil.UserAmountToWei(Amount, big.NewInt(nativeDecimals))
+	if err != nil {
+		return err
+	}
+	if RealAmount.Sign() != 1 {
+		return fmt.Errorf("deposit amount has to be positive, got %s", Amount)
+	}
+	ResourceIdBytesArr, err = flags.ProcessResourceID(ResourceID)
+	return err
+}
+
+func DepositCmd(cmd *cobra.Command, args []string, contract *bridge.BridgeContract) error {
+	hash, err := contract.NativeDeposit(
+		RecipientAddress, RealAmount, ResourceIdBytesArr,
+		DomainID, transactor.TransactOptions{GasLimit: gasLimit},
+	)
+	if err != nil {
+		log.Error().Err(err).Msg("native deposit error")
+		return err
+	}
+
+	fmt.Printf(
+		"%s ether was transferred to %s from %s with hash %s\n",
+		Amount, RecipientAddress.Hex(), senderKeyPair.CommonAddress().String(), hash.Hex(),
+	)
+	return nil
+}
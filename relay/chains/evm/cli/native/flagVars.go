@@ -0,0 +1,34 @@
+package native
+
+import (
+	"math/big"
+
+	"github.com/mpetrun5/diplomski-projekt/crypto/secp256k1"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//flag vars
+var (
+	Amount     string
+	Recipient  string
+	Bridge     string
+	DomainID   uint8
+	ResourceID string
+)
+
+//processed flag vars
+var (
+	RecipientAddress   common.Address
+	RealAmount         *big.Int
+	BridgeAddr         common.Address
+	ResourceIdBytesArr [32]byte
+)
+
+// global flags
+var (
+	url           string
+	gasLimit      uint64
+	gasPrice      *big.Int
+	senderKeyPair *secp256k1.Keypair
+)
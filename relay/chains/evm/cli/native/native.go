@@ -0,0 +1,26 @@
+package native
+
+import (
+	"fmt"
+
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/cli/flags"
+	"github.com/spf13/cobra"
+)
+
+var NativeCmd = &cobra.Command{
+	Use:   "native",
+	Short: "Set of commands for transferring native currency",
+	Long:  "Set of commands for transferring native currency",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		var err error
+		url, gasLimit, gasPrice, senderKeyPair, err = flags.GlobalFlagValues(cmd)
+		if err != nil {
+			return fmt.Errorf("could not get global flags: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	NativeCmd.AddCommand(depositCmd)
+}
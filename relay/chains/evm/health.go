@@ -0,0 +1,35 @@
+package evm
+
+import (
+	"time"
+
+	"github.com/mpetrun5/diplomski-projekt/health"
+)
+
+// HealthStatus reports listener progress, chain head and whether the relayer is allowed to vote
+func (c *EVMChain) HealthStatus() health.ChainStatus {
+	status := health.ChainStatus{
+		DomainID: c.DomainID(),
+	}
+
+	lastProcessedBlock, lastProgress := c.listener.Progress()
+	status.LastProcessedBlock = lastProcessedBlock
+	status.SecondsSinceProgress = time.Since(lastProgress).Seconds()
+
+	head, err := c.client.LatestBlock()
+	if err != nil {
+		status.Errors = append(status.Errors, err.Error())
+	} else {
+		status.Head = head
+		status.RPCReachable = true
+	}
+
+	isRelayer, err := c.bridgeContract.IsRelayer(c.client.RelayerAddress())
+	if err != nil {
+		status.Errors = append(status.Errors, err.Error())
+	} else {
+		status.HasRelayerRole = isRelayer
+	}
+
+	return status
+}
@@ -2,8 +2,11 @@ package listener
 
 import (
 	"errors"
+	"fmt"
+	"math/big"
 
 	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/contracts/bridge"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/contracts/deposit"
 	"github.com/mpetrun5/diplomski-projekt/relayer/message"
 	"github.com/rs/zerolog/log"
 
@@ -11,7 +14,7 @@ import (
 )
 
 type EventHandlers map[common.Address]EventHandlerFunc
-type EventHandlerFunc func(sourceID, destId uint8, nonce uint64, resourceID [32]byte, calldata, handlerResponse []byte) (*message.Message, error)
+type EventHandlerFunc func(sourceID, destId uint8, nonce uint64, resourceID [32]byte, calldata, handlerResponse []byte, blockNumber uint64, txHash common.Hash, logIndex uint) (*message.Message, error)
 
 type ETHEventHandler struct {
 	bridgeContract bridge.BridgeContract
@@ -24,7 +27,7 @@ func NewETHEventHandler(bridgeContract bridge.BridgeContract) *ETHEventHandler {
 	}
 }
 
-func (e *ETHEventHandler) HandleEvent(sourceID, destID uint8, depositNonce uint64, resourceID [32]byte, calldata, handlerResponse []byte) (*message.Message, error) {
+func (e *ETHEventHandler) HandleEvent(sourceID, destID uint8, depositNonce uint64, resourceID [32]byte, calldata, handlerResponse []byte, blockNumber uint64, txHash common.Hash, logIndex uint) (*message.Message, error) {
 	handlerAddr, err := e.bridgeContract.GetHandlerAddressForResourceID(resourceID)
 	if err != nil {
 		return nil, err
@@ -35,7 +38,7 @@ func (e *ETHEventHandler) HandleEvent(sourceID, destID uint8, depositNonce uint6
 		return nil, err
 	}
 
-	return eventHandler(sourceID, destID, depositNonce, resourceID, calldata, handlerResponse)
+	return eventHandler(sourceID, destID, depositNonce, resourceID, calldata, handlerResponse, blockNumber, txHash, logIndex)
 }
 
 func (e *ETHEventHandler) matchAddressWithHandlerFunc(handlerAddress common.Address) (EventHandlerFunc, error) {
@@ -60,13 +63,13 @@ func (e *ETHEventHandler) RegisterEventHandler(handlerAddress string, handler Ev
 	e.eventHandlers[common.HexToAddress(handlerAddress)] = handler
 }
 
-func Erc20EventHandler(sourceID, destId uint8, nonce uint64, resourceID [32]byte, calldata, handlerResponse []byte) (*message.Message, error) {
+func Erc20EventHandler(sourceID, destId uint8, nonce uint64, resourceID [32]byte, calldata, handlerResponse []byte, blockNumber uint64, txHash common.Hash, logIndex uint) (*message.Message, error) {
 	if len(calldata) < 84 {
 		err := errors.New("invalid calldata length: less than 84 bytes")
 		return nil, err
 	}
 
-	amount := calldata[:32]
+	amount := new(big.Int).SetBytes(calldata[:32])
 	recipientAddress := calldata[64:]
 
 	return &message.Message{
@@ -75,9 +78,121 @@ func Erc20EventHandler(sourceID, destId uint8, nonce uint64, resourceID [32]byte
 		DepositNonce: nonce,
 		ResourceId:   resourceID,
 		Type:         message.FungibleTransfer,
-		Payload: []interface{}{
-			amount,
-			recipientAddress,
+		BlockNumber:  blockNumber,
+		TxHash:       txHash,
+		Index:        logIndex,
+		Payload: &message.FungibleTransferPayload{
+			Amount:    amount,
+			Recipient: recipientAddress,
+		},
+	}, nil
+}
+
+// NativeEventHandler handles deposits of native currency. Native deposit data has the same
+// layout as ERC20 deposit data and is relayed as a fungible transfer of the deposited amount.
+func NativeEventHandler(sourceID, destId uint8, nonce uint64, resourceID [32]byte, calldata, handlerResponse []byte, blockNumber uint64, txHash common.Hash, logIndex uint) (*message.Message, error) {
+	return Erc20EventHandler(sourceID, destId, nonce, resourceID, calldata, handlerResponse, blockNumber, txHash, logIndex)
+}
+
+func Erc721EventHandler(sourceID, destId uint8, nonce uint64, resourceID [32]byte, calldata, handlerResponse []byte, blockNumber uint64, txHash common.Hash, logIndex uint) (*message.Message, error) {
+	if len(calldata) < 64 {
+		err := errors.New("invalid calldata length: less than 64 bytes")
+		return nil, err
+	}
+
+	// first 32 bytes are tokenId, followed by recipient address length and recipient address
+	tokenId := new(big.Int).SetBytes(calldata[:32])
+	recipientAddressLength := new(big.Int).SetBytes(calldata[32:64])
+	metadataStart := new(big.Int).Add(big.NewInt(64), recipientAddressLength)
+	if !metadataStart.IsInt64() || int64(len(calldata)) < metadataStart.Int64() {
+		return nil, errors.New("invalid calldata length: recipient address out of bounds")
+	}
+	recipientAddress := calldata[64:metadataStart.Int64()]
+
+	// recipient address is followed by metadata length and metadata, which are optional
+	var metadata []byte
+	if int64(len(calldata)) > metadataStart.Int64() {
+		metadataLengthEnd := metadataStart.Int64() + 32
+		if int64(len(calldata)) < metadataLengthEnd {
+			return nil, errors.New("invalid calldata length: metadata length out of bounds")
+		}
+		metadataLength := new(big.Int).SetBytes(calldata[metadataStart.Int64():metadataLengthEnd])
+		metadataEnd := new(big.Int).Add(big.NewInt(metadataLengthEnd), metadataLength)
+		if !metadataEnd.IsInt64() || int64(len(calldata)) < metadataEnd.Int64() {
+			return nil, errors.New("invalid calldata length: metadata out of bounds")
+		}
+		metadata = calldata[metadataLengthEnd:metadataEnd.Int64()]
+	}
+
+	return &message.Message{
+		Source:       sourceID,
+		Destination:  destId,
+		DepositNonce: nonce,
+		ResourceId:   resourceID,
+		Type:         message.NonFungibleTransfer,
+		BlockNumber:  blockNumber,
+		TxHash:       txHash,
+		Index:        logIndex,
+		Payload: &message.NonFungibleTransferPayload{
+			TokenID:   tokenId,
+			Recipient: recipientAddress,
+			Metadata:  metadata,
+		},
+	}, nil
+}
+
+func Erc1155EventHandler(sourceID, destId uint8, nonce uint64, resourceID [32]byte, calldata, handlerResponse []byte, blockNumber uint64, txHash common.Hash, logIndex uint) (*message.Message, error) {
+	depositData, err := deposit.ParseErc1155DepositData(calldata)
+	if err != nil {
+		return nil, fmt.Errorf("invalid erc1155 calldata: %w", err)
+	}
+	if len(depositData.TokenIDs) != len(depositData.Amounts) {
+		return nil, fmt.Errorf("token IDs and amounts length mismatch: %v != %v", len(depositData.TokenIDs), len(depositData.Amounts))
+	}
+
+	return &message.Message{
+		Source:       sourceID,
+		Destination:  destId,
+		DepositNonce: nonce,
+		ResourceId:   resourceID,
+		Type:         message.SemiFungibleTransfer,
+		BlockNumber:  blockNumber,
+		TxHash:       txHash,
+		Index:        logIndex,
+		Payload: &message.SemiFungibleTransferPayload{
+			TokenIDs:     depositData.TokenIDs,
+			Amounts:      depositData.Amounts,
+			Recipient:    depositData.Recipient,
+			TransferData: depositData.TransferData,
+		},
+	}, nil
+}
+
+func GenericEventHandler(sourceID, destId uint8, nonce uint64, resourceID [32]byte, calldata, handlerResponse []byte, blockNumber uint64, txHash common.Hash, logIndex uint) (*message.Message, error) {
+	if len(calldata) < 32 {
+		err := errors.New("invalid calldata length: less than 32 bytes")
+		return nil, err
+	}
+
+	// first 32 bytes are metadata length, metadata is relayed unchanged
+	metadataLength := new(big.Int).SetBytes(calldata[:32])
+	metadataEnd := new(big.Int).Add(big.NewInt(32), metadataLength)
+	if !metadataEnd.IsInt64() || int64(len(calldata)) < metadataEnd.Int64() {
+		return nil, errors.New("invalid calldata length: metadata out of bounds")
+	}
+	metadata := calldata[32:metadataEnd.Int64()]
+
+	return &message.Message{
+		Source:       sourceID,
+		Destination:  destId,
+		DepositNonce: nonce,
+		ResourceId:   resourceID,
+		Type:         message.GenericTransfer,
+		BlockNumber:  blockNumber,
+		TxHash:       txHash,
+		Index:        logIndex,
+		Payload: &message.GenericTransferPayload{
+			Metadata: metadata,
 		},
 	}, nil
 }
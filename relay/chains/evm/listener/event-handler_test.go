@@ -0,0 +1,122 @@
+package listener
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/contracts/deposit"
+	"github.com/mpetrun5/diplomski-projekt/relayer/message"
+)
+
+var testRecipient = common.HexToAddress("0x62877dDCd49aD22f5eDfc6ac108e9a4b5D2bD88B").Bytes()
+
+func TestErc721EventHandler(t *testing.T) {
+	withMetadata := deposit.ConstructErc721DepositData(testRecipient, big.NewInt(42), []byte("ipfs://token"))
+	withoutMetadata := withMetadata[:64+len(testRecipient)]
+
+	tests := []struct {
+		name     string
+		calldata []byte
+		metadata []byte
+		wantErr  bool
+	}{
+		{name: "with metadata", calldata: withMetadata, metadata: []byte("ipfs://token")},
+		{name: "without metadata", calldata: withoutMetadata},
+		{name: "shorter than token ID and recipient length", calldata: withMetadata[:63], wantErr: true},
+		{name: "recipient out of bounds", calldata: withMetadata[:64+len(testRecipient)-1], wantErr: true},
+		{name: "metadata length out of bounds", calldata: withMetadata[:64+len(testRecipient)+31], wantErr: true},
+		{name: "metadata out of bounds", calldata: withMetadata[:len(withMetadata)-1], wantErr: true},
+		{
+			name:     "recipient length overflows",
+			calldata: append(math.PaddedBigBytes(big.NewInt(42), 32), math.PaddedBigBytes(math.MaxBig256, 32)...),
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := Erc721EventHandler(1, 2, 3, [32]byte{1}, tt.calldata, nil, 10, common.Hash{}, 0)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			p, err := m.NonFungiblePayload()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if m.Type != message.NonFungibleTransfer || p.TokenID.Int64() != 42 {
+				t.Fatalf("unexpected message %+v", m)
+			}
+			if !bytes.Equal(p.Recipient, testRecipient) {
+				t.Fatalf("expected recipient %x, got %x", testRecipient, p.Recipient)
+			}
+			if !bytes.Equal(p.Metadata, tt.metadata) {
+				t.Fatalf("expected metadata %x, got %x", tt.metadata, p.Metadata)
+			}
+		})
+	}
+}
+
+func TestErc1155EventHandler(t *testing.T) {
+	tokenIDs := []*big.Int{big.NewInt(1), big.NewInt(2)}
+	amounts := []*big.Int{big.NewInt(10), big.NewInt(20)}
+	calldata, err := deposit.ConstructErc1155DepositData(testRecipient, tokenIDs, amounts, []byte{0xab})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mismatched, err := deposit.ConstructErc1155DepositData(testRecipient, tokenIDs, amounts[:1], nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		calldata []byte
+		wantErr  bool
+	}{
+		{name: "batch deposit", calldata: calldata},
+		{name: "token IDs and amounts length mismatch", calldata: mismatched, wantErr: true},
+		{name: "truncated calldata", calldata: calldata[:len(calldata)-32], wantErr: true},
+		{name: "empty calldata", calldata: []byte{}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := Erc1155EventHandler(1, 2, 3, [32]byte{1}, tt.calldata, nil, 10, common.Hash{}, 0)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			p, err := m.SemiFungiblePayload()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if m.Type != message.SemiFungibleTransfer || len(p.TokenIDs) != 2 || len(p.Amounts) != 2 {
+				t.Fatalf("unexpected message %+v", m)
+			}
+			for i := range tokenIDs {
+				if p.TokenIDs[i].Cmp(tokenIDs[i]) != 0 || p.Amounts[i].Cmp(amounts[i]) != 0 {
+					t.Fatalf("expected token %v amount %v, got token %v amount %v", tokenIDs[i], amounts[i], p.TokenIDs[i], p.Amounts[i])
+				}
+			}
+			if !bytes.Equal(p.Recipient, testRecipient) || !bytes.Equal(p.TransferData, []byte{0xab}) {
+				t.Fatalf("unexpected recipient %x or transfer data %x", p.Recipient, p.TransferData)
+			}
+		})
+	}
+}
@@ -5,12 +5,21 @@ package listener
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"math/big"
+	"math/rand"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/evmclient"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/mpetrun5/diplomski-projekt/relayer/message"
 	"github.com/mpetrun5/diplomski-projekt/store"
 
@@ -18,27 +27,152 @@ import (
 )
 
 var (
-	blockRetryInterval = 10 * time.Second
-	blockDelay         = big.NewInt(3)
+	baseFailureBackoff = time.Second
+	maxFailureBackoff  = time.Minute
+	// maxConsecutiveFailures is the number of failures in a row after which the listener gives up
+	maxConsecutiveFailures = 10
+	// blockRecordsRetained is the number of latest processed block ranges whose hashes are kept
+	// to find the common ancestor on reorg
+	blockRecordsRetained = 128
 )
 
+// methodNotFoundCode is the JSON-RPC error code returned by nodes that don't expose eth_subscribe
+const methodNotFoundCode = -32601
+
+// tooManyResultsErrors are substrings of errors returned by nodes and RPC providers
+// when a logs query covers too many blocks or returns too many logs
+var tooManyResultsErrors = []string{
+	"too many results",
+	"query returned more than",
+	"limit exceeded",
+	"response size exceeded",
+	"block range is too large",
+	"range too large",
+}
+
 type EventHandler interface {
-	HandleEvent(sourceID, destID uint8, nonce uint64, resourceID [32]byte, calldata, handlerResponse []byte) (*message.Message, error)
+	HandleEvent(sourceID, destID uint8, nonce uint64, resourceID [32]byte, calldata, handlerResponse []byte, blockNumber uint64, txHash common.Hash, logIndex uint) (*message.Message, error)
 }
 type ChainClient interface {
 	LatestBlock() (*big.Int, error)
+	TaggedBlock(tag string) (*big.Int, error)
 	FetchDepositLogs(ctx context.Context, address common.Address, startBlock *big.Int, endBlock *big.Int) ([]*evmclient.DepositLogs, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
 	CallContract(ctx context.Context, callArgs map[string]interface{}, blockNumber *big.Int) ([]byte, error)
 }
 
+// HeadSubscriber pushes new heads and deposit logs to the listener
+type HeadSubscriber interface {
+	SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error)
+	SubscribeDepositLogs(ctx context.Context, contractAddress common.Address, ch chan<- types.Log) (ethereum.Subscription, error)
+}
+
+type Metrics interface {
+	TrackHeadLag(domainID uint8, lag *big.Int)
+	TrackBlockstoreHeight(domainID uint8, block *big.Int)
+	TrackReorg(domainID uint8)
+}
+
+type MessageQueue interface {
+	Enqueue(m *message.Message) error
+	Remove(m *message.Message) error
+}
+
+// HoldQueue holds rate limited messages and tracks the rate limit usage of relayed messages
+type HoldQueue interface {
+	RetractMessage(m *message.Message) error
+}
+
+// DeadLetterQueue holds messages whose delivery failed after all retries
+type DeadLetterQueue interface {
+	RemoveFailedMessage(m *message.Message) error
+}
+
+// UnhandledDepositStore records deposits that could not be turned into messages
+type UnhandledDepositStore interface {
+	StoreUnhandledDeposit(d *store.UnhandledDeposit) error
+}
+
 type EVMListener struct {
-	chainReader   ChainClient
-	eventHandler  EventHandler
-	bridgeAddress common.Address
+	chainReader       ChainClient
+	eventHandler      EventHandler
+	bridgeAddress     common.Address
+	queue             MessageQueue
+	holdQueue         HoldQueue
+	deadLetterQueue   DeadLetterQueue
+	unhandledDeposits UnhandledDepositStore
+	metrics           Metrics
+	subscriber        HeadSubscriber
+
+	blockConfirmations *big.Int
+	blockRetryInterval time.Duration
+	// blockInterval is the maximum number of blocks fetched in a single logs query
+	blockInterval *big.Int
+	// blockTag is the tag of the block followed as the head, the latest block if empty
+	blockTag string
+
+	progressLock       sync.RWMutex
+	lastProcessedBlock *big.Int
+	lastProgress       time.Time
+}
+
+// NewEVMListener creates a listener that polls for new blocks every blockRetryInterval. If subscriber
+// is not nil, the listener is woken up by new heads and deposit logs instead of waiting for the next poll.
+// Blocks are processed once they are blockConfirmations behind the head, which is the block with blockTag
+// or the latest block if blockTag is empty.
+func NewEVMListener(
+	chainReader ChainClient,
+	handler EventHandler,
+	bridgeAddress common.Address,
+	queue MessageQueue,
+	holdQueue HoldQueue,
+	deadLetterQueue DeadLetterQueue,
+	unhandledDeposits UnhandledDepositStore,
+	metrics Metrics,
+	subscriber HeadSubscriber,
+	blockConfirmations *big.Int,
+	blockRetryInterval time.Duration,
+	blockInterval *big.Int,
+	blockTag string,
+) *EVMListener {
+	return &EVMListener{
+		chainReader:        chainReader,
+		eventHandler:       handler,
+		bridgeAddress:      bridgeAddress,
+		queue:              queue,
+		holdQueue:          holdQueue,
+		deadLetterQueue:    deadLetterQueue,
+		unhandledDeposits:  unhandledDeposits,
+		metrics:            metrics,
+		subscriber:         subscriber,
+		blockConfirmations: blockConfirmations,
+		blockRetryInterval: blockRetryInterval,
+		blockInterval:      blockInterval,
+		blockTag:           blockTag,
+		lastProgress:       time.Now(),
+	}
+}
+
+// Progress returns the last block processed by the listener and the time the listener
+// last made progress, either by processing a block or by confirming it is caught up with the head
+func (l *EVMListener) Progress() (*big.Int, time.Time) {
+	l.progressLock.RLock()
+	defer l.progressLock.RUnlock()
+
+	if l.lastProcessedBlock == nil {
+		return nil, l.lastProgress
+	}
+	return new(big.Int).Set(l.lastProcessedBlock), l.lastProgress
 }
 
-func NewEVMListener(chainReader ChainClient, handler EventHandler, bridgeAddress common.Address) *EVMListener {
-	return &EVMListener{chainReader: chainReader, eventHandler: handler, bridgeAddress: bridgeAddress}
+func (l *EVMListener) markProgress(processedBlock *big.Int) {
+	l.progressLock.Lock()
+	defer l.progressLock.Unlock()
+
+	if processedBlock != nil {
+		l.lastProcessedBlock = new(big.Int).Set(processedBlock)
+	}
+	l.lastProgress = time.Now()
 }
 
 func (l *EVMListener) ListenToEvents(
@@ -50,44 +184,494 @@ func (l *EVMListener) ListenToEvents(
 ) <-chan *message.Message {
 	ch := make(chan *message.Message)
 	go func() {
+		// closing the channel signals that the listener stopped and won't write to the blockstore anymore
+		defer close(ch)
+		rangeSize := new(big.Int).Set(l.blockInterval)
+		recordsChecked := false
+		// wake stays nil without a subscriber, so waiting for a block falls back to polling
+		var wake chan struct{}
+		if l.subscriber != nil {
+			wake = make(chan struct{}, 1)
+			go l.subscribe(domainID, wake, stopChn)
+		}
+		failures := &listenerFailures{}
 		for {
 			select {
 			case <-stopChn:
 				return
 			default:
-				head, err := l.chainReader.LatestBlock()
+				head, err := l.head()
 				if err != nil {
-					time.Sleep(blockRetryInterval)
+					if !l.handleFailure(domainID, failures, startBlock, "Failed fetching chain head", err, stopChn, errChn) {
+						return
+					}
 					continue
 				}
 				if startBlock == nil {
 					startBlock = head
 				}
-				if big.NewInt(0).Sub(head, startBlock).Cmp(blockDelay) == -1 {
-					time.Sleep(blockRetryInterval)
+				if !recordsChecked {
+					err = l.removeBlockRecordsFrom(domainID, startBlock, blockstore)
+					if err != nil {
+						if !l.handleFailure(domainID, failures, startBlock, "Failed removing stale block records", err, stopChn, errChn) {
+							return
+						}
+						continue
+					}
+					recordsChecked = true
+				}
+				l.metrics.TrackHeadLag(domainID, big.NewInt(0).Sub(head, startBlock))
+				if big.NewInt(0).Sub(head, startBlock).Cmp(l.blockConfirmations) == -1 {
+					failures.reset()
+					l.markProgress(nil)
+					waitForBlock(wake, l.blockRetryInterval, stopChn)
+					continue
+				}
+
+				startBlock, err = l.checkReorg(domainID, startBlock, blockstore)
+				if err != nil {
+					if !l.handleFailure(domainID, failures, startBlock, "Failed checking for chain reorganization", err, stopChn, errChn) {
+						return
+					}
+					continue
+				}
+
+				endBlock := l.rangeEnd(startBlock, head, rangeSize)
+				endHeader, err := l.chainReader.HeaderByNumber(context.Background(), endBlock)
+				if err != nil {
+					if !l.handleFailure(domainID, failures, startBlock, "Failed fetching range end header", err, stopChn, errChn) {
+						return
+					}
 					continue
 				}
-				logs, err := l.chainReader.FetchDepositLogs(context.Background(), l.bridgeAddress, startBlock, startBlock)
+				logs, err := l.chainReader.FetchDepositLogs(context.Background(), l.bridgeAddress, startBlock, endBlock)
 				if err != nil {
+					if isTooManyResults(err) && rangeSize.Cmp(big.NewInt(1)) == 1 {
+						rangeSize.Rsh(rangeSize, 1)
+						log.Warn().Str("startBlock", startBlock.String()).Str("rangeSize", rangeSize.String()).Err(err).Msg("Too many logs in block range, reducing range size")
+						continue
+					}
+					if !l.handleFailure(domainID, failures, startBlock, "Failed fetching deposit logs", err, stopChn, errChn) {
+						return
+					}
 					continue
 				}
+				// logs are returned in block order so messages are emitted in the order they were deposited
+				msgs := make([]*message.Message, 0)
+				unhandled := make([]*store.UnhandledDeposit, 0)
 				for _, eventLog := range logs {
-					log.Debug().Msgf("Deposit log found from sender: %s in block: %s with  destinationDomainId: %v, resourceID: %s, depositNonce: %v", eventLog.SenderAddress, startBlock.String(), eventLog.DestinationDomainID, eventLog.ResourceID, eventLog.DepositNonce)
-					m, err := l.eventHandler.HandleEvent(domainID, eventLog.DestinationDomainID, eventLog.DepositNonce, eventLog.ResourceID, eventLog.Data, eventLog.HandlerResponse)
+					log.Debug().Msgf("Deposit log found from sender: %s in block: %d, tx: %s, log index: %d with  destinationDomainId: %v, resourceID: %s, depositNonce: %v", eventLog.SenderAddress, eventLog.BlockNumber, eventLog.TxHash, eventLog.Index, eventLog.DestinationDomainID, eventLog.ResourceID, eventLog.DepositNonce)
+					m, err := l.eventHandler.HandleEvent(domainID, eventLog.DestinationDomainID, eventLog.DepositNonce, eventLog.ResourceID, eventLog.Data, eventLog.HandlerResponse, eventLog.BlockNumber, eventLog.TxHash, eventLog.Index)
 					if err != nil {
+						log.Warn().
+							Uint8("domainID", domainID).
+							Uint8("destination", eventLog.DestinationDomainID).
+							Uint64("depositNonce", eventLog.DepositNonce).
+							Uint64("block", eventLog.BlockNumber).
+							Str("tx", eventLog.TxHash.Hex()).
+							Uint("logIndex", eventLog.Index).
+							Str("resourceID", hexutil.Encode(eventLog.ResourceID[:])).
+							Err(err).
+							Msg("Failed handling deposit, recording it as unhandled")
+						unhandled = append(unhandled, &store.UnhandledDeposit{
+							Source:          domainID,
+							Destination:     eventLog.DestinationDomainID,
+							DepositNonce:    eventLog.DepositNonce,
+							ResourceID:      eventLog.ResourceID,
+							Data:            eventLog.Data,
+							HandlerResponse: eventLog.HandlerResponse,
+							StartBlock:      new(big.Int).Set(startBlock),
+							EndBlock:        new(big.Int).Set(endBlock),
+							BlockNumber:     eventLog.BlockNumber,
+							TxHash:          eventLog.TxHash,
+							Index:           eventLog.Index,
+							Error:           err.Error(),
+							RecordedAt:      time.Now(),
+						})
 						continue
 					} else {
-						log.Debug().Msgf("Resolved message %+v in block %s", m, startBlock.String())
-						ch <- m
+						log.Debug().Msgf("Resolved message %+v in blocks %s-%s", m, startBlock.String(), endBlock.String())
+						msgs = append(msgs, m)
+					}
+				}
+				err = l.storeUnhandledDeposits(unhandled)
+				if err != nil {
+					if !l.handleFailure(domainID, failures, startBlock, "Failed storing unhandled deposits", err, stopChn, errChn) {
+						return
+					}
+					continue
+				}
+				// messages are persisted before the range is marked as processed so that
+				// they are not lost if the relayer stops before delivering them
+				err = l.enqueueMessages(msgs)
+				if err != nil {
+					if !l.handleFailure(domainID, failures, startBlock, "Failed to enqueue messages", err, stopChn, errChn) {
+						return
+					}
+					continue
+				}
+				failures.reset()
+				for _, m := range msgs {
+					select {
+					case ch <- m:
+					case <-stopChn:
+						// message is already queued and is going to be delivered on the next start
+						return
 					}
 				}
-				err = blockstore.StoreBlock(startBlock, domainID)
+				err = l.storeBlockRecord(domainID, startBlock, endBlock, endHeader.Hash(), msgs, blockstore)
 				if err != nil {
-					log.Error().Str("block", startBlock.String()).Err(err).Msg("Failed to write latest block to blockstore")
+					log.Error().Str("block", endBlock.String()).Err(err).Msg("Failed to store block record")
 				}
-				startBlock.Add(startBlock, big.NewInt(1))
+				err = blockstore.StoreBlock(endBlock, domainID)
+				if err != nil {
+					log.Error().Str("block", endBlock.String()).Err(err).Msg("Failed to write latest block to blockstore")
+				} else {
+					l.metrics.TrackBlockstoreHeight(domainID, endBlock)
+				}
+				l.markProgress(endBlock)
+				startBlock = new(big.Int).Add(endBlock, big.NewInt(1))
+
+				l.recoverRangeSize(rangeSize)
 			}
 		}
 	}()
 	return ch
 }
+
+// checkReorg compares the parent hash of the block following the last processed range with the
+// stored hash of the range end. On mismatch the listener rewinds to the common ancestor and
+// returns the block to continue from.
+func (l *EVMListener) checkReorg(domainID uint8, startBlock *big.Int, blockstore *store.BlockStore) (*big.Int, error) {
+	records, err := blockstore.BlockRecords(domainID)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return startBlock, nil
+	}
+
+	last := records[len(records)-1]
+	header, err := l.chainReader.HeaderByNumber(context.Background(), new(big.Int).Add(last.EndBlock, big.NewInt(1)))
+	if err != nil {
+		return nil, err
+	}
+	if header.ParentHash == last.Hash {
+		return startBlock, nil
+	}
+	return l.rewind(domainID, records, blockstore)
+}
+
+// rewind finds the latest block range whose end block is still canonical, retracts messages
+// from ranges after it and moves the blockstore back to its end block. If no stored range is
+// canonical anymore the listener rewinds to the start of the oldest stored range.
+func (l *EVMListener) rewind(domainID uint8, records []*store.BlockRecord, blockstore *store.BlockStore) (*big.Int, error) {
+	rewindTo := records[0].StartBlock
+	ancestor := len(records) - 1
+	for ; ancestor >= 0; ancestor-- {
+		header, err := l.chainReader.HeaderByNumber(context.Background(), records[ancestor].EndBlock)
+		if err != nil {
+			return nil, err
+		}
+		if header.Hash() == records[ancestor].Hash {
+			rewindTo = new(big.Int).Add(records[ancestor].EndBlock, big.NewInt(1))
+			break
+		}
+	}
+	if ancestor < 0 {
+		log.Error().Uint8("domainID", domainID).Str("block", rewindTo.String()).Msg("Reorg is deeper than stored block history, rewinding to the oldest stored block")
+	}
+
+	retracted := 0
+	for i := len(records) - 1; i > ancestor; i-- {
+		for _, m := range records[i].Messages {
+			err := l.retractMessage(m)
+			if err != nil {
+				return nil, err
+			}
+			retracted++
+		}
+		err := blockstore.RemoveBlockRecord(domainID, records[i].EndBlock)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	lastProcessed := new(big.Int).Sub(rewindTo, big.NewInt(1))
+	err := blockstore.StoreBlock(lastProcessed, domainID)
+	if err != nil {
+		return nil, err
+	}
+
+	l.metrics.TrackReorg(domainID)
+	log.Warn().
+		Uint8("domainID", domainID).
+		Str("lastProcessedBlock", lastProcessed.String()).
+		Str("depth", new(big.Int).Sub(records[len(records)-1].EndBlock, lastProcessed).String()).
+		Int("retractedMessages", retracted).
+		Msg("Chain reorganization detected, rewinding listener")
+	return rewindTo, nil
+}
+
+// retractMessage removes the message wherever the relayer moved it, so that a deposit which
+// no longer exists can't be released or replayed and doesn't count against rate limits
+func (l *EVMListener) retractMessage(m *message.Message) error {
+	err := l.queue.Remove(m)
+	if err != nil {
+		return err
+	}
+	err = l.holdQueue.RetractMessage(m)
+	if err != nil {
+		return err
+	}
+	return l.deadLetterQueue.RemoveFailedMessage(m)
+}
+
+func (l *EVMListener) storeBlockRecord(domainID uint8, startBlock, endBlock *big.Int, hash common.Hash, msgs []*message.Message, blockstore *store.BlockStore) error {
+	err := blockstore.StoreBlockRecord(domainID, &store.BlockRecord{
+		StartBlock: new(big.Int).Set(startBlock),
+		EndBlock:   new(big.Int).Set(endBlock),
+		Hash:       hash,
+		Messages:   msgs,
+	})
+	if err != nil {
+		return err
+	}
+	return blockstore.PruneBlockRecords(domainID, blockRecordsRetained)
+}
+
+// removeBlockRecordsFrom removes records of ranges that are processed again, which happens
+// when the listener starts from an earlier block than it previously processed
+func (l *EVMListener) removeBlockRecordsFrom(domainID uint8, startBlock *big.Int, blockstore *store.BlockStore) error {
+	records, err := blockstore.BlockRecords(domainID)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range records {
+		if r.EndBlock.Cmp(startBlock) == -1 {
+			continue
+		}
+		err = blockstore.RemoveBlockRecord(domainID, r.EndBlock)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// head returns the block the listener follows as the chain head
+func (l *EVMListener) head() (*big.Int, error) {
+	if l.blockTag == "" {
+		return l.chainReader.LatestBlock()
+	}
+	return l.chainReader.TaggedBlock(l.blockTag)
+}
+
+// rangeEnd returns the last block of the range starting at startBlock that is at most
+// rangeSize blocks long and does not include unconfirmed blocks
+func (l *EVMListener) rangeEnd(startBlock, head, rangeSize *big.Int) *big.Int {
+	endBlock := new(big.Int).Add(startBlock, rangeSize)
+	endBlock.Sub(endBlock, big.NewInt(1))
+
+	lastConfirmed := new(big.Int).Sub(head, l.blockConfirmations)
+	if endBlock.Cmp(lastConfirmed) == 1 {
+		endBlock = lastConfirmed
+	}
+	return endBlock
+}
+
+// recoverRangeSize doubles the range size reduced after too many results, up to blockInterval,
+// so that it recovers gradually
+func (l *EVMListener) recoverRangeSize(rangeSize *big.Int) {
+	if rangeSize.Cmp(l.blockInterval) == -1 {
+		rangeSize.Lsh(rangeSize, 1)
+		if rangeSize.Cmp(l.blockInterval) == 1 {
+			rangeSize.Set(l.blockInterval)
+		}
+	}
+}
+
+func isTooManyResults(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, e := range tooManyResultsErrors {
+		if strings.Contains(msg, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// subscribe keeps new head and deposit log subscriptions alive, signaling wake on every
+// notification. While the subscriptions are down the listener keeps polling. If the endpoint
+// doesn't support subscriptions the listener only polls from then on.
+func (l *EVMListener) subscribe(domainID uint8, wake chan<- struct{}, stopChn <-chan struct{}) {
+	for {
+		err := l.watch(domainID, wake, stopChn)
+		select {
+		case <-stopChn:
+			return
+		default:
+		}
+		if isSubscriptionUnsupported(err) {
+			log.Warn().Uint8("domainID", domainID).Err(err).Msg("Endpoint doesn't support subscriptions, polling for new blocks")
+			return
+		}
+		if err != nil {
+			log.Warn().Uint8("domainID", domainID).Err(err).Msg("Subscription dropped, falling back to polling")
+		}
+		sleep(l.blockRetryInterval, stopChn)
+	}
+}
+
+// isSubscriptionUnsupported reports whether the error means the endpoint can't serve subscriptions,
+// either because the transport has no notifications or the node doesn't expose eth_subscribe
+func isSubscriptionUnsupported(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, rpc.ErrNotificationsUnsupported) {
+		return true
+	}
+	var rpcErr rpc.Error
+	return errors.As(err, &rpcErr) && rpcErr.ErrorCode() == methodNotFoundCode
+}
+
+func (l *EVMListener) watch(domainID uint8, wake chan<- struct{}, stopChn <-chan struct{}) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	heads := make(chan *types.Header)
+	headSub, err := l.subscriber.SubscribeNewHead(ctx, heads)
+	if err != nil {
+		return err
+	}
+	defer headSub.Unsubscribe()
+
+	logs := make(chan types.Log)
+	logSub, err := l.subscriber.SubscribeDepositLogs(ctx, l.bridgeAddress, logs)
+	if err != nil {
+		return err
+	}
+	defer logSub.Unsubscribe()
+
+	log.Info().Uint8("domainID", domainID).Msg("Subscribed to new heads and deposit logs")
+	for {
+		select {
+		case <-heads:
+			notify(wake)
+		case <-logs:
+			notify(wake)
+		case err := <-headSub.Err():
+			return err
+		case err := <-logSub.Err():
+			return err
+		case <-stopChn:
+			return nil
+		}
+	}
+}
+
+// notify signals wake without blocking, a pending signal already wakes up the listener
+func notify(wake chan<- struct{}) {
+	select {
+	case wake <- struct{}{}:
+	default:
+	}
+}
+
+// waitForBlock waits for a wake up signal, the next poll after d or until stop is closed
+func waitForBlock(wake <-chan struct{}, d time.Duration, stopChn <-chan struct{}) {
+	select {
+	case <-wake:
+	case <-time.After(d):
+	case <-stopChn:
+	}
+}
+
+// handleFailure logs the failure and waits with backoff before the next attempt. Once the listener
+// fails maxConsecutiveFailures times in a row the error is reported to errChn and false is returned,
+// meaning the listener has to stop.
+func (l *EVMListener) handleFailure(
+	domainID uint8,
+	failures *listenerFailures,
+	block *big.Int,
+	msg string,
+	err error,
+	stopChn <-chan struct{},
+	errChn chan<- error,
+) bool {
+	backoff := failures.record()
+	log.Error().
+		Uint8("domainID", domainID).
+		Str("block", block.String()).
+		Int("consecutiveFailures", failures.count).
+		Dur("backoff", backoff).
+		Err(err).
+		Msg(msg)
+
+	if failures.count >= maxConsecutiveFailures {
+		select {
+		case errChn <- fmt.Errorf("listener of domain %v failed %v consecutive times, last error: %s: %w", domainID, failures.count, msg, err):
+		case <-stopChn:
+		}
+		return false
+	}
+
+	sleep(backoff, stopChn)
+	return true
+}
+
+func (l *EVMListener) storeUnhandledDeposits(deposits []*store.UnhandledDeposit) error {
+	for _, d := range deposits {
+		err := l.unhandledDeposits.StoreUnhandledDeposit(d)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *EVMListener) enqueueMessages(msgs []*message.Message) error {
+	for _, m := range msgs {
+		err := l.queue.Enqueue(m)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listenerFailures counts consecutive listener failures and tracks the backoff before the next attempt
+type listenerFailures struct {
+	count   int
+	backoff time.Duration
+}
+
+// record registers a failure and returns the backoff doubled from the previous one, with jitter
+// so that relayers sharing a node don't retry in lockstep
+func (f *listenerFailures) record() time.Duration {
+	f.count++
+	if f.backoff == 0 {
+		f.backoff = baseFailureBackoff
+	} else {
+		f.backoff *= 2
+	}
+	if f.backoff > maxFailureBackoff {
+		f.backoff = maxFailureBackoff
+	}
+	return f.backoff/2 + time.Duration(rand.Int63n(int64(f.backoff/2)+1))
+}
+
+func (f *listenerFailures) reset() {
+	f.count = 0
+	f.backoff = 0
+}
+
+// sleep pauses the listener for the provided duration or until stop is closed
+func sleep(d time.Duration, stopChn <-chan struct{}) {
+	select {
+	case <-time.After(d):
+	case <-stopChn:
+	}
+}
@@ -0,0 +1,346 @@
+package listener
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/evmclient"
+	"github.com/mpetrun5/diplomski-projekt/lvldb"
+	"github.com/mpetrun5/diplomski-projekt/relayer/message"
+	"github.com/mpetrun5/diplomski-projekt/store"
+)
+
+const testDomainID = 1
+
+// fakeChain serves headers of a chain whose blocks from forkBlock on are replaced by a fork
+type fakeChain struct {
+	lock      sync.Mutex
+	head      *big.Int
+	forkBlock uint64
+	headers   map[uint64]*types.Header
+	// fetchLogs is called on every logs query and defaults to returning no logs
+	fetchLogs func(startBlock, endBlock *big.Int) ([]*evmclient.DepositLogs, error)
+}
+
+func newFakeChain(head int64) *fakeChain {
+	return &fakeChain{head: big.NewInt(head), headers: make(map[uint64]*types.Header)}
+}
+
+// reorg replaces blocks from forkBlock on with blocks of a different fork
+func (c *fakeChain) reorg(forkBlock uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.forkBlock = forkBlock
+	c.headers = make(map[uint64]*types.Header)
+}
+
+func (c *fakeChain) header(number uint64) *types.Header {
+	if h, ok := c.headers[number]; ok {
+		return h
+	}
+
+	h := &types.Header{Number: new(big.Int).SetUint64(number)}
+	if number > 0 {
+		h.ParentHash = c.header(number - 1).Hash()
+	}
+	if c.forkBlock != 0 && number >= c.forkBlock {
+		h.Extra = []byte("fork")
+	}
+	c.headers[number] = h
+	return h
+}
+
+func (c *fakeChain) LatestBlock() (*big.Int, error) {
+	return new(big.Int).Set(c.head), nil
+}
+
+func (c *fakeChain) TaggedBlock(tag string) (*big.Int, error) {
+	return new(big.Int).Set(c.head), nil
+}
+
+func (c *fakeChain) FetchDepositLogs(ctx context.Context, address common.Address, startBlock *big.Int, endBlock *big.Int) ([]*evmclient.DepositLogs, error) {
+	if c.fetchLogs == nil {
+		return nil, nil
+	}
+	return c.fetchLogs(startBlock, endBlock)
+}
+
+func (c *fakeChain) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.header(number.Uint64()), nil
+}
+
+func (c *fakeChain) CallContract(ctx context.Context, callArgs map[string]interface{}, blockNumber *big.Int) ([]byte, error) {
+	return nil, errors.New("not supported")
+}
+
+// retractedMessages records messages removed from every store the listener retracts them from
+type retractedMessages struct {
+	queued       []*message.Message
+	held         []*message.Message
+	deadLettered []*message.Message
+}
+
+func (r *retractedMessages) Enqueue(m *message.Message) error {
+	return nil
+}
+
+func (r *retractedMessages) Remove(m *message.Message) error {
+	r.queued = append(r.queued, m)
+	return nil
+}
+
+func (r *retractedMessages) RetractMessage(m *message.Message) error {
+	r.held = append(r.held, m)
+	return nil
+}
+
+func (r *retractedMessages) RemoveFailedMessage(m *message.Message) error {
+	r.deadLettered = append(r.deadLettered, m)
+	return nil
+}
+
+type noopMetrics struct{}
+
+func (noopMetrics) TrackHeadLag(domainID uint8, lag *big.Int)            {}
+func (noopMetrics) TrackBlockstoreHeight(domainID uint8, block *big.Int) {}
+func (noopMetrics) TrackReorg(domainID uint8)                            {}
+
+func newTestBlockStore(t *testing.T) *store.BlockStore {
+	db, err := lvldb.NewLvlDB(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		db.Close()
+	})
+	return store.NewBlockStore(db)
+}
+
+func newTestListener(chain *fakeChain, retracted *retractedMessages, blockInterval int64) *EVMListener {
+	return NewEVMListener(
+		chain,
+		nil,
+		common.Address{},
+		retracted,
+		retracted,
+		retracted,
+		nil,
+		noopMetrics{},
+		nil,
+		big.NewInt(0),
+		10*time.Millisecond,
+		big.NewInt(blockInterval),
+		"",
+	)
+}
+
+// storeRecords stores records of consecutive block ranges ending at the provided blocks
+func storeRecords(t *testing.T, chain *fakeChain, blockstore *store.BlockStore, msgs map[int64][]*message.Message, endBlocks ...int64) {
+	startBlock := int64(1)
+	for _, endBlock := range endBlocks {
+		header, _ := chain.HeaderByNumber(context.Background(), big.NewInt(endBlock))
+		err := blockstore.StoreBlockRecord(testDomainID, &store.BlockRecord{
+			StartBlock: big.NewInt(startBlock),
+			EndBlock:   big.NewInt(endBlock),
+			Hash:       header.Hash(),
+			Messages:   msgs[endBlock],
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		startBlock = endBlock + 1
+	}
+	err := blockstore.StoreBlock(big.NewInt(startBlock-1), testDomainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCheckReorg_ContinuesWithoutReorg(t *testing.T) {
+	chain := newFakeChain(100)
+	blockstore := newTestBlockStore(t)
+	retracted := &retractedMessages{}
+	storeRecords(t, chain, blockstore, nil, 10, 20)
+
+	startBlock, err := newTestListener(chain, retracted, 10).checkReorg(testDomainID, big.NewInt(21), blockstore)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if startBlock.Int64() != 21 {
+		t.Fatalf("expected listener to continue from block 21, got %v", startBlock)
+	}
+	if len(retracted.queued) != 0 {
+		t.Fatalf("expected no retracted messages, got %v", len(retracted.queued))
+	}
+}
+
+func TestCheckReorg_RewindsToCommonAncestor(t *testing.T) {
+	chain := newFakeChain(100)
+	blockstore := newTestBlockStore(t)
+	retracted := &retractedMessages{}
+	kept := &message.Message{Source: testDomainID, DepositNonce: 1}
+	reorged := &message.Message{Source: testDomainID, DepositNonce: 2}
+	storeRecords(t, chain, blockstore, map[int64][]*message.Message{10: {kept}, 20: {reorged}}, 10, 20)
+	chain.reorg(15)
+
+	startBlock, err := newTestListener(chain, retracted, 10).checkReorg(testDomainID, big.NewInt(21), blockstore)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if startBlock.Int64() != 11 {
+		t.Fatalf("expected listener to rewind to block 11, got %v", startBlock)
+	}
+	for name, msgs := range map[string][]*message.Message{
+		"queue":       retracted.queued,
+		"hold queue":  retracted.held,
+		"dead-letter": retracted.deadLettered,
+	} {
+		if len(msgs) != 1 || msgs[0].DepositNonce != reorged.DepositNonce {
+			t.Fatalf("expected reorged message to be retracted from %s, got %v", name, msgs)
+		}
+	}
+	records, err := blockstore.BlockRecords(testDomainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0].EndBlock.Int64() != 10 {
+		t.Fatalf("expected only the record of the canonical range to be kept, got %v records", len(records))
+	}
+	lastBlock, err := blockstore.GetLastStoredBlock(testDomainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lastBlock.Int64() != 10 {
+		t.Fatalf("expected blockstore to be rewound to block 10, got %v", lastBlock)
+	}
+}
+
+func TestCheckReorg_RewindsToOldestRecordOnDeepReorg(t *testing.T) {
+	chain := newFakeChain(100)
+	blockstore := newTestBlockStore(t)
+	retracted := &retractedMessages{}
+	storeRecords(t, chain, blockstore, nil, 10, 20)
+	chain.reorg(5)
+
+	startBlock, err := newTestListener(chain, retracted, 10).checkReorg(testDomainID, big.NewInt(21), blockstore)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if startBlock.Int64() != 1 {
+		t.Fatalf("expected listener to rewind to the oldest stored block 1, got %v", startBlock)
+	}
+	records, err := blockstore.BlockRecords(testDomainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 0 {
+		t.Fatalf("expected all records to be removed, got %v", len(records))
+	}
+}
+
+func TestRangeEnd(t *testing.T) {
+	tests := []struct {
+		name          string
+		startBlock    int64
+		head          int64
+		rangeSize     int64
+		confirmations int64
+		endBlock      int64
+	}{
+		{name: "full range", startBlock: 10, head: 100, rangeSize: 20, endBlock: 29},
+		{name: "range capped by head", startBlock: 90, head: 100, rangeSize: 20, endBlock: 100},
+		{name: "range capped by confirmations", startBlock: 90, head: 100, rangeSize: 20, confirmations: 5, endBlock: 95},
+		{name: "single block", startBlock: 10, head: 100, rangeSize: 1, endBlock: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newTestListener(newFakeChain(tt.head), &retractedMessages{}, tt.rangeSize)
+			l.blockConfirmations = big.NewInt(tt.confirmations)
+
+			endBlock := l.rangeEnd(big.NewInt(tt.startBlock), big.NewInt(tt.head), big.NewInt(tt.rangeSize))
+			if endBlock.Int64() != tt.endBlock {
+				t.Fatalf("expected range end %v, got %v", tt.endBlock, endBlock)
+			}
+		})
+	}
+}
+
+func TestRecoverRangeSize(t *testing.T) {
+	tests := []struct {
+		rangeSize int64
+		recovered int64
+	}{
+		{rangeSize: 1, recovered: 2},
+		{rangeSize: 16, recovered: 32},
+		{rangeSize: 40, recovered: 50},
+		{rangeSize: 50, recovered: 50},
+	}
+
+	l := newTestListener(newFakeChain(100), &retractedMessages{}, 50)
+	for _, tt := range tests {
+		rangeSize := big.NewInt(tt.rangeSize)
+		l.recoverRangeSize(rangeSize)
+		if rangeSize.Int64() != tt.recovered {
+			t.Fatalf("expected range size %v to recover to %v, got %v", tt.rangeSize, tt.recovered, rangeSize)
+		}
+	}
+}
+
+func TestIsTooManyResults(t *testing.T) {
+	tests := []struct {
+		err         error
+		tooManyLogs bool
+	}{
+		{err: errors.New("query returned more than 10000 results"), tooManyLogs: true},
+		{err: errors.New("Log response size exceeded. You can make eth_getLogs requests with up to a 2K block range"), tooManyLogs: true},
+		{err: errors.New("block range is too large"), tooManyLogs: true},
+		{err: errors.New("connection refused")},
+	}
+
+	for _, tt := range tests {
+		if isTooManyResults(tt.err) != tt.tooManyLogs {
+			t.Fatalf("expected %q to be classified as too many results: %v", tt.err, tt.tooManyLogs)
+		}
+	}
+}
+
+func TestListenToEvents_ShrinksRangeOnTooManyResults(t *testing.T) {
+	chain := newFakeChain(100)
+	fetched := make(chan [2]int64, 100)
+	chain.fetchLogs = func(startBlock, endBlock *big.Int) ([]*evmclient.DepositLogs, error) {
+		if new(big.Int).Sub(endBlock, startBlock).Int64()+1 > 8 {
+			return nil, errors.New("query returned more than 10000 results")
+		}
+		fetched <- [2]int64{startBlock.Int64(), endBlock.Int64()}
+		return nil, nil
+	}
+	stop := make(chan struct{})
+	l := newTestListener(chain, &retractedMessages{}, 32)
+	msgs := l.ListenToEvents(big.NewInt(1), testDomainID, newTestBlockStore(t), stop, make(chan error, 1))
+
+	next := int64(1)
+	for next <= 100 {
+		select {
+		case r := <-fetched:
+			if r[0] != next {
+				t.Fatalf("expected range to start at block %v, got %v", next, r[0])
+			}
+			next = r[1] + 1
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for blocks from %v", next)
+		}
+	}
+	close(stop)
+	for range msgs {
+	}
+}
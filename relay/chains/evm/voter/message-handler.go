@@ -1,12 +1,12 @@
 package voter
 
 import (
-	"errors"
 	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/contracts/bridge"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/calls/contracts/deposit"
 	"github.com/mpetrun5/diplomski-projekt/chains/evm/voter/proposal"
 	"github.com/mpetrun5/diplomski-projekt/relayer/message"
 	"github.com/rs/zerolog/log"
@@ -63,18 +63,60 @@ func (mh *EVMMessageHandler) RegisterMessageHandler(address string, handler Mess
 }
 
 func ERC20MessageHandler(m *message.Message, handlerAddr, bridgeAddress common.Address) (*proposal.Proposal, error) {
-	amount, ok := m.Payload[0].([]byte)
-	if !ok {
-		return nil, errors.New("wrong payloads amount format")
+	payload, err := m.FungiblePayload()
+	if err != nil {
+		return nil, err
 	}
-	recipient, ok := m.Payload[1].([]byte)
-	if !ok {
-		return nil, errors.New("wrong payloads recipient format")
+	var data []byte
+	data = append(data, common.LeftPadBytes(payload.Amount.Bytes(), 32)...)
+	recipientLen := big.NewInt(int64(len(payload.Recipient))).Bytes()
+	data = append(data, common.LeftPadBytes(recipientLen, 32)...)
+	data = append(data, payload.Recipient...)
+	return proposal.NewProposal(m.Source, m.DepositNonce, m.ResourceId, data, handlerAddr, bridgeAddress), nil
+}
+
+// NativeMessageHandler builds proposal data for the native handler, which releases locked
+// currency or mints its wrapped equivalent to the recipient
+func NativeMessageHandler(m *message.Message, handlerAddr, bridgeAddress common.Address) (*proposal.Proposal, error) {
+	return ERC20MessageHandler(m, handlerAddr, bridgeAddress)
+}
+
+func ERC721MessageHandler(m *message.Message, handlerAddr, bridgeAddress common.Address) (*proposal.Proposal, error) {
+	payload, err := m.NonFungiblePayload()
+	if err != nil {
+		return nil, err
 	}
 	var data []byte
-	data = append(data, common.LeftPadBytes(amount, 32)...)
-	recipientLen := big.NewInt(int64(len(recipient))).Bytes()
+	data = append(data, common.LeftPadBytes(payload.TokenID.Bytes(), 32)...)
+	recipientLen := big.NewInt(int64(len(payload.Recipient))).Bytes()
 	data = append(data, common.LeftPadBytes(recipientLen, 32)...)
-	data = append(data, recipient...)
+	data = append(data, payload.Recipient...)
+	metadataLen := big.NewInt(int64(len(payload.Metadata))).Bytes()
+	data = append(data, common.LeftPadBytes(metadataLen, 32)...)
+	data = append(data, payload.Metadata...)
+	return proposal.NewProposal(m.Source, m.DepositNonce, m.ResourceId, data, handlerAddr, bridgeAddress), nil
+}
+
+func ERC1155MessageHandler(m *message.Message, handlerAddr, bridgeAddress common.Address) (*proposal.Proposal, error) {
+	payload, err := m.SemiFungiblePayload()
+	if err != nil {
+		return nil, err
+	}
+	data, err := deposit.ConstructErc1155DepositData(payload.Recipient, payload.TokenIDs, payload.Amounts, payload.TransferData)
+	if err != nil {
+		return nil, err
+	}
+	return proposal.NewProposal(m.Source, m.DepositNonce, m.ResourceId, data, handlerAddr, bridgeAddress), nil
+}
+
+func GenericMessageHandler(m *message.Message, handlerAddr, bridgeAddress common.Address) (*proposal.Proposal, error) {
+	payload, err := m.GenericPayload()
+	if err != nil {
+		return nil, err
+	}
+	var data []byte
+	metadataLen := big.NewInt(int64(len(payload.Metadata))).Bytes()
+	data = append(data, common.LeftPadBytes(metadataLen, 32)...)
+	data = append(data, payload.Metadata...)
 	return proposal.NewProposal(m.Source, m.DepositNonce, m.ResourceId, data, handlerAddr, bridgeAddress), nil
 }
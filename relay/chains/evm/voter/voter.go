@@ -58,6 +58,12 @@ func (v *EVMVoter) VoteProposal(m *message.Message) error {
 		return fmt.Errorf("voting failed. Err: %w", err)
 	}
 
-	log.Debug().Str("hash", hash.String()).Uint64("nonce", prop.DepositNonce).Msgf("Voted")
+	log.Debug().
+		Str("hash", hash.String()).
+		Uint64("nonce", prop.DepositNonce).
+		Str("sourceTx", m.TxHash.Hex()).
+		Uint64("sourceBlock", m.BlockNumber).
+		Uint("sourceLogIndex", m.Index).
+		Msgf("Voted")
 	return nil
 }
@@ -0,0 +1,24 @@
+package accounts
+
+import (
+	"github.com/mpetrun5/diplomski-projekt/flags"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var AccountsCmd = &cobra.Command{
+	Use:   "accounts",
+	Short: "Set of commands for managing relayer keystore",
+	Long:  "Set of commands for managing relayer keystore",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		keystorePath = viper.GetString(flags.KeystoreFlagName)
+		return nil
+	},
+}
+
+func init() {
+	AccountsCmd.AddCommand(generateCmd)
+	AccountsCmd.AddCommand(importCmd)
+	AccountsCmd.AddCommand(listCmd)
+	AccountsCmd.AddCommand(exportAddressCmd)
+}
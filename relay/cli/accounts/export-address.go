@@ -0,0 +1,56 @@
+package accounts
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/cli/flags"
+	"github.com/mpetrun5/diplomski-projekt/keystore"
+	"github.com/spf13/cobra"
+)
+
+var exportAddressCmd = &cobra.Command{
+	Use:   "export-address",
+	Short: "Print address and public key of a stored key",
+	Long:  "Decrypt a stored key and print its address and public key without revealing the private key",
+	RunE:  ExportAddressCmd,
+	Args: func(cmd *cobra.Command, args []string) error {
+		err := ValidateExportAddressFlags(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		ProcessExportAddressFlags(cmd, args)
+		return nil
+	},
+}
+
+func BindExportAddressFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&Address, "address", "", "Address of the stored key")
+	flags.MarkFlagsAsRequired(cmd, "address")
+}
+
+func init() {
+	BindExportAddressFlags(exportAddressCmd)
+}
+
+func ValidateExportAddressFlags(cmd *cobra.Command, args []string) error {
+	if !common.IsHexAddress(Address) {
+		return fmt.Errorf("invalid address %s", Address)
+	}
+	return nil
+}
+
+func ProcessExportAddressFlags(cmd *cobra.Command, args []string) {
+	AccountAddress = common.HexToAddress(Address)
+}
+
+func ExportAddressCmd(cmd *cobra.Command, args []string) error {
+	kp, err := keystore.KeypairFromAddress(AccountAddress.Hex(), keystorePath, false)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Address: %s\nPublic key: %s\n", kp.Address(), kp.PublicKey())
+	return nil
+}
@@ -0,0 +1,23 @@
+package accounts
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//flag vars
+var (
+	PrivateKey         string
+	JsonWallet         string
+	JsonWalletPassword string
+	Address            string
+)
+
+//processed flag vars
+var (
+	AccountAddress common.Address
+)
+
+// global flags
+var (
+	keystorePath string
+)
@@ -0,0 +1,36 @@
+package accounts
+
+import (
+	"fmt"
+
+	"github.com/mpetrun5/diplomski-projekt/crypto/secp256k1"
+	"github.com/mpetrun5/diplomski-projekt/keystore"
+	"github.com/spf13/cobra"
+)
+
+var generateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate a new keypair into the keystore",
+	Long:  "Generate a new secp256k1 keypair and store it encrypted into the keystore directory",
+	RunE:  GenerateCmd,
+}
+
+func GenerateCmd(cmd *cobra.Command, args []string) error {
+	kp, err := secp256k1.GenerateKeypair()
+	if err != nil {
+		return err
+	}
+
+	password, err := keystore.GetNewPassword("Enter password to encrypt the key:")
+	if err != nil {
+		return err
+	}
+
+	file, err := keystore.StoreKeypair(kp, keystorePath, password)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Generated key with address %s stored in %s\n", kp.Address(), file)
+	return nil
+}
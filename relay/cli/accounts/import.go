@@ -0,0 +1,83 @@
+package accounts
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/mpetrun5/diplomski-projekt/crypto/secp256k1"
+	"github.com/mpetrun5/diplomski-projekt/keystore"
+	"github.com/spf13/cobra"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import a key into the keystore",
+	Long:  "Import a raw hex private key or an existing encrypted JSON wallet into the keystore directory",
+	RunE:  ImportCmd,
+	Args: func(cmd *cobra.Command, args []string) error {
+		return ValidateImportFlags(cmd, args)
+	},
+}
+
+func BindImportFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&PrivateKey, "private-key", "", "Hex encoded private key to import")
+	cmd.Flags().StringVar(&JsonWallet, "json-wallet", "", "Path to the encrypted JSON wallet to import")
+	cmd.Flags().StringVar(&JsonWalletPassword, "json-wallet-password", "", "Password of the imported JSON wallet")
+}
+
+func init() {
+	BindImportFlags(importCmd)
+}
+
+func ValidateImportFlags(cmd *cobra.Command, args []string) error {
+	if PrivateKey == "" && JsonWallet == "" {
+		return errors.New("either --private-key or --json-wallet has to be provided")
+	}
+	if PrivateKey != "" && JsonWallet != "" {
+		return errors.New("only one of --private-key and --json-wallet can be provided")
+	}
+	return nil
+}
+
+func ImportCmd(cmd *cobra.Command, args []string) error {
+	kp, err := importedKeypair()
+	if err != nil {
+		return err
+	}
+
+	password, err := keystore.GetNewPassword("Enter password to encrypt the key:")
+	if err != nil {
+		return err
+	}
+
+	file, err := keystore.StoreKeypair(kp, keystorePath, password)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Imported key with address %s stored in %s\n", kp.Address(), file)
+	return nil
+}
+
+func importedKeypair() (*secp256k1.Keypair, error) {
+	if PrivateKey != "" {
+		if len(PrivateKey) > 2 && PrivateKey[0:2] == "0x" {
+			PrivateKey = PrivateKey[2:]
+		}
+		return secp256k1.NewKeypairFromString(PrivateKey)
+	}
+
+	keyJSON, err := ioutil.ReadFile(JsonWallet)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading JSON wallet: %w", err)
+	}
+	password := JsonWalletPassword
+	if password == "" {
+		password, err = keystore.PromptPassword("Enter password of the JSON wallet:")
+		if err != nil {
+			return nil, err
+		}
+	}
+	return keystore.KeypairFromJSON(keyJSON, password)
+}
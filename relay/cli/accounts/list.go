@@ -0,0 +1,31 @@
+package accounts
+
+import (
+	"fmt"
+
+	"github.com/mpetrun5/diplomski-projekt/keystore"
+	"github.com/spf13/cobra"
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List keys stored in the keystore",
+	Long:  "List addresses and file paths of all keys stored in the keystore directory",
+	RunE:  ListCmd,
+}
+
+func ListCmd(cmd *cobra.Command, args []string) error {
+	keys, err := keystore.ListKeys(keystorePath)
+	if err != nil {
+		return err
+	}
+
+	if len(keys) == 0 {
+		fmt.Printf("No keys found in %s\n", keystorePath)
+		return nil
+	}
+	for i, k := range keys {
+		fmt.Printf("[%d] %s %s\n", i, k.Address.Hex(), k.Path)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package deposits
+
+import (
+	"github.com/mpetrun5/diplomski-projekt/flags"
+	"github.com/mpetrun5/diplomski-projekt/lvldb"
+	"github.com/mpetrun5/diplomski-projekt/store"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var DepositsCmd = &cobra.Command{
+	Use:   "deposits",
+	Short: "Set of commands for inspecting deposits the relayer could not handle",
+	Long: "Set of commands for inspecting deposits the listener found but could not turn into messages. " +
+		"The store can be opened by a single process so the relayer has to be stopped while running these commands.",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		var err error
+		db, err = lvldb.NewLvlDB(viper.GetString(flags.BlockstoreFlagName))
+		if err != nil {
+			return err
+		}
+		unhandledDepositStore = store.NewUnhandledDepositStore(db)
+		return nil
+	},
+	PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
+		return db.Close()
+	},
+}
+
+func init() {
+	DepositsCmd.AddCommand(listCmd)
+}
@@ -0,0 +1,17 @@
+package deposits
+
+import (
+	"github.com/mpetrun5/diplomski-projekt/lvldb"
+	"github.com/mpetrun5/diplomski-projekt/store"
+)
+
+//flag vars
+var (
+	Source uint8
+)
+
+// global vars
+var (
+	db                    *lvldb.LVLDB
+	unhandledDepositStore *store.UnhandledDepositStore
+)
@@ -0,0 +1,51 @@
+package deposits
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/mpetrun5/diplomski-projekt/store"
+	"github.com/spf13/cobra"
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List unhandled deposits",
+	Long:  "List unhandled deposits for all source domains or for a single source domain",
+	RunE:  ListCmd,
+}
+
+func BindListFlags(cmd *cobra.Command) {
+	cmd.Flags().Uint8Var(&Source, "source", 0, "Source domain ID")
+}
+
+func init() {
+	BindListFlags(listCmd)
+}
+
+func ListCmd(cmd *cobra.Command, args []string) error {
+	var deposits []*store.UnhandledDeposit
+	var err error
+	if cmd.Flags().Changed("source") {
+		deposits, err = unhandledDepositStore.UnhandledDeposits(Source)
+	} else {
+		deposits, err = unhandledDepositStore.AllUnhandledDeposits()
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(deposits) == 0 {
+		fmt.Println("No unhandled deposits found")
+		return nil
+	}
+	for _, d := range deposits {
+		fmt.Printf(
+			"source: %v destination: %v nonce: %v resource: %s block: %v tx: %s log index: %v recorded at: %s error: %s\n",
+			d.Source, d.Destination, d.DepositNonce, hexutil.Encode(d.ResourceID[:]),
+			d.BlockNumber, d.TxHash.Hex(), d.Index, d.RecordedAt.Format(time.RFC3339), d.Error,
+		)
+	}
+	return nil
+}
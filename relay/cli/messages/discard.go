@@ -0,0 +1,37 @@
+package messages
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var discardCmd = &cobra.Command{
+	Use:   "discard",
+	Short: "Discard failed messages",
+	Long:  "Permanently remove failed messages from the store",
+	RunE:  DiscardCmd,
+	Args: func(cmd *cobra.Command, args []string) error {
+		return ValidateSelectionFlags(cmd, args)
+	},
+}
+
+func init() {
+	BindSelectionFlags(discardCmd)
+}
+
+func DiscardCmd(cmd *cobra.Command, args []string) error {
+	msgs, err := selectedMessages()
+	if err != nil {
+		return err
+	}
+
+	for _, fm := range msgs {
+		err = deadLetterStore.RemoveFailedMessage(fm.Message)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Message from %v with nonce %v to %v discarded\n", fm.Message.Source, fm.Message.DepositNonce, fm.Message.Destination)
+	}
+	return nil
+}
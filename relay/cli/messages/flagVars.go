@@ -0,0 +1,21 @@
+package messages
+
+import (
+	"github.com/mpetrun5/diplomski-projekt/lvldb"
+	"github.com/mpetrun5/diplomski-projekt/store"
+)
+
+//flag vars
+var (
+	Destination uint8
+	Source      uint8
+	Nonce       uint64
+	All         bool
+)
+
+// global vars
+var (
+	db              *lvldb.LVLDB
+	deadLetterStore *store.DeadLetterStore
+	queueStore      *store.MessageQueueStore
+)
@@ -0,0 +1,50 @@
+package messages
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mpetrun5/diplomski-projekt/store"
+	"github.com/spf13/cobra"
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List failed messages",
+	Long:  "List failed messages for all destination domains or for a single destination domain",
+	RunE:  ListCmd,
+}
+
+func BindListFlags(cmd *cobra.Command) {
+	cmd.Flags().Uint8Var(&Destination, "destination", 0, "Destination domain ID")
+}
+
+func init() {
+	BindListFlags(listCmd)
+}
+
+func ListCmd(cmd *cobra.Command, args []string) error {
+	var msgs []*store.FailedMessage
+	var err error
+	if cmd.Flags().Changed("destination") {
+		msgs, err = deadLetterStore.FailedMessages(Destination)
+	} else {
+		msgs, err = deadLetterStore.AllFailedMessages()
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(msgs) == 0 {
+		fmt.Println("No failed messages found")
+		return nil
+	}
+	for _, fm := range msgs {
+		fmt.Printf(
+			"destination: %v source: %v nonce: %v attempts: %v last failed at: %s error: %s\n",
+			fm.Message.Destination, fm.Message.Source, fm.Message.DepositNonce,
+			fm.Attempts, fm.LastFailedAt.Format(time.RFC3339), fm.LastError,
+		)
+	}
+	return nil
+}
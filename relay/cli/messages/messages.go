@@ -0,0 +1,37 @@
+package messages
+
+import (
+	"github.com/mpetrun5/diplomski-projekt/flags"
+	"github.com/mpetrun5/diplomski-projekt/lvldb"
+	"github.com/mpetrun5/diplomski-projekt/store"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var MessagesCmd = &cobra.Command{
+	Use:   "messages",
+	Short: "Set of commands for inspecting and recovering failed messages",
+	Long: "Set of commands for inspecting and recovering messages whose delivery failed after all retries. " +
+		"The store can be opened by a single process so the relayer has to be stopped while running these commands. " +
+		"Replayed messages are delivered once the relayer is started again.",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		var err error
+		db, err = lvldb.NewLvlDB(viper.GetString(flags.BlockstoreFlagName))
+		if err != nil {
+			return err
+		}
+		deadLetterStore = store.NewDeadLetterStore(db)
+		queueStore = store.NewMessageQueueStore(db)
+		return nil
+	},
+	PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
+		return db.Close()
+	},
+}
+
+func init() {
+	MessagesCmd.AddCommand(listCmd)
+	MessagesCmd.AddCommand(showCmd)
+	MessagesCmd.AddCommand(replayCmd)
+	MessagesCmd.AddCommand(discardCmd)
+}
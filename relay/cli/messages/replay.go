@@ -0,0 +1,41 @@
+package messages
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var replayCmd = &cobra.Command{
+	Use:   "replay",
+	Short: "Replay failed messages",
+	Long:  "Move failed messages back to the delivery queue so that they are delivered once the relayer starts",
+	RunE:  ReplayCmd,
+	Args: func(cmd *cobra.Command, args []string) error {
+		return ValidateSelectionFlags(cmd, args)
+	},
+}
+
+func init() {
+	BindSelectionFlags(replayCmd)
+}
+
+func ReplayCmd(cmd *cobra.Command, args []string) error {
+	msgs, err := selectedMessages()
+	if err != nil {
+		return err
+	}
+
+	for _, fm := range msgs {
+		err = queueStore.Enqueue(fm.Message)
+		if err != nil {
+			return err
+		}
+		err = deadLetterStore.RemoveFailedMessage(fm.Message)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Message from %v with nonce %v queued for replay to %v\n", fm.Message.Source, fm.Message.DepositNonce, fm.Message.Destination)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package messages
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/cli/flags"
+	"github.com/mpetrun5/diplomski-projekt/relayer/message"
+	"github.com/spf13/cobra"
+)
+
+var showCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show a failed message in detail",
+	Long:  "Show a failed message in detail",
+	RunE:  ShowCmd,
+}
+
+func BindShowFlags(cmd *cobra.Command) {
+	cmd.Flags().Uint8Var(&Destination, "destination", 0, "Destination domain ID")
+	cmd.Flags().Uint8Var(&Source, "source", 0, "Source domain ID")
+	cmd.Flags().Uint64Var(&Nonce, "nonce", 0, "Deposit nonce")
+	flags.MarkFlagsAsRequired(cmd, "destination", "source", "nonce")
+}
+
+func init() {
+	BindShowFlags(showCmd)
+}
+
+func ShowCmd(cmd *cobra.Command, args []string) error {
+	fm, err := deadLetterStore.GetFailedMessage(Destination, Source, Nonce)
+	if err != nil {
+		return err
+	}
+
+	m := fm.Message
+	fmt.Printf("Source: %v\n", m.Source)
+	fmt.Printf("Destination: %v\n", m.Destination)
+	fmt.Printf("Deposit nonce: %v\n", m.DepositNonce)
+	fmt.Printf("Resource ID: %s\n", hexutil.Encode(m.ResourceId[:]))
+	fmt.Printf("Type: %s\n", m.Type)
+	fmt.Printf("Source block: %v\n", m.BlockNumber)
+	fmt.Printf("Source tx: %s\n", m.TxHash.Hex())
+	fmt.Printf("Source log index: %v\n", m.Index)
+	printPayload(m.Payload)
+	fmt.Printf("Attempts: %v\n", fm.Attempts)
+	fmt.Printf("First failed at: %s\n", fm.FirstFailedAt.Format(time.RFC3339))
+	fmt.Printf("Last failed at: %s\n", fm.LastFailedAt.Format(time.RFC3339))
+	fmt.Printf("Last error: %s\n", fm.LastError)
+	return nil
+}
+
+func printPayload(payload message.Payload) {
+	switch p := payload.(type) {
+	case *message.FungibleTransferPayload:
+		fmt.Printf("Amount: %s\n", p.Amount)
+		fmt.Printf("Recipient: %s\n", hexutil.Encode(p.Recipient))
+	case *message.NonFungibleTransferPayload:
+		fmt.Printf("Token ID: %s\n", p.TokenID)
+		fmt.Printf("Recipient: %s\n", hexutil.Encode(p.Recipient))
+		fmt.Printf("Metadata: %s\n", hexutil.Encode(p.Metadata))
+	case *message.SemiFungibleTransferPayload:
+		if len(p.TokenIDs) != len(p.Amounts) {
+			fmt.Printf("Token IDs and amounts length mismatch: %v != %v\n", len(p.TokenIDs), len(p.Amounts))
+		}
+		for i := 0; i < len(p.TokenIDs) && i < len(p.Amounts); i++ {
+			fmt.Printf("Token ID: %s amount: %s\n", p.TokenIDs[i], p.Amounts[i])
+		}
+		fmt.Printf("Recipient: %s\n", hexutil.Encode(p.Recipient))
+		fmt.Printf("Transfer data: %s\n", hexutil.Encode(p.TransferData))
+	case *message.GenericTransferPayload:
+		fmt.Printf("Metadata: %s\n", hexutil.Encode(p.Metadata))
+	default:
+		fmt.Printf("Payload: %v\n", p)
+	}
+}
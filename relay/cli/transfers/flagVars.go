@@ -0,0 +1,23 @@
+package transfers
+
+import (
+	"github.com/mpetrun5/diplomski-projekt/lvldb"
+	"github.com/mpetrun5/diplomski-projekt/store"
+)
+
+//flag vars
+var (
+	Destination uint8
+	Source      uint8
+	Nonce       uint64
+	All         bool
+	Reason      string
+)
+
+// global vars
+var (
+	db              *lvldb.LVLDB
+	rateLimitStore  *store.RateLimitStore
+	deadLetterStore *store.DeadLetterStore
+	queueStore      *store.MessageQueueStore
+)
@@ -0,0 +1,51 @@
+package transfers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/mpetrun5/diplomski-projekt/store"
+	"github.com/spf13/cobra"
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List held transfers",
+	Long:  "List held transfers for all destination domains or for a single destination domain",
+	RunE:  ListCmd,
+}
+
+func BindListFlags(cmd *cobra.Command) {
+	cmd.Flags().Uint8Var(&Destination, "destination", 0, "Destination domain ID")
+}
+
+func init() {
+	BindListFlags(listCmd)
+}
+
+func ListCmd(cmd *cobra.Command, args []string) error {
+	var msgs []*store.HeldMessage
+	var err error
+	if cmd.Flags().Changed("destination") {
+		msgs, err = rateLimitStore.HeldMessages(Destination)
+	} else {
+		msgs, err = rateLimitStore.AllHeldMessages()
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(msgs) == 0 {
+		fmt.Println("No held transfers found")
+		return nil
+	}
+	for _, hm := range msgs {
+		fmt.Printf(
+			"destination: %v source: %v nonce: %v resource ID: %s held at: %s reason: %s\n",
+			hm.Message.Destination, hm.Message.Source, hm.Message.DepositNonce,
+			hexutil.Encode(hm.Message.ResourceId[:]), hm.HeldAt.Format(time.RFC3339), hm.Reason,
+		)
+	}
+	return nil
+}
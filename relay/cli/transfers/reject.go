@@ -0,0 +1,46 @@
+package transfers
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var rejectCmd = &cobra.Command{
+	Use:   "reject",
+	Short: "Reject held transfers",
+	Long:  "Reject held transfers so they are never relayed. Rejected transfers are recorded in the failed messages store.",
+	RunE:  RejectCmd,
+	Args: func(cmd *cobra.Command, args []string) error {
+		return ValidateSelectionFlags(cmd, args)
+	},
+}
+
+func BindRejectFlags(cmd *cobra.Command) {
+	BindSelectionFlags(cmd)
+	cmd.Flags().StringVar(&Reason, "reason", "rejected by operator", "Reason recorded with the rejected transfer")
+}
+
+func init() {
+	BindRejectFlags(rejectCmd)
+}
+
+func RejectCmd(cmd *cobra.Command, args []string) error {
+	msgs, err := selectedTransfers()
+	if err != nil {
+		return err
+	}
+
+	for _, hm := range msgs {
+		err = deadLetterStore.StoreFailedMessage(hm.Message, fmt.Errorf("%s: %s", Reason, hm.Reason), 0, hm.HeldAt)
+		if err != nil {
+			return err
+		}
+		err = rateLimitStore.RemoveHeldMessage(hm.Message)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Transfer from %v with nonce %v to %v rejected\n", hm.Message.Source, hm.Message.DepositNonce, hm.Message.Destination)
+	}
+	return nil
+}
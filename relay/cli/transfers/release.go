@@ -0,0 +1,45 @@
+package transfers
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var releaseCmd = &cobra.Command{
+	Use:   "release",
+	Short: "Release held transfers",
+	Long:  "Move held transfers back to the delivery queue. Released transfers are relayed regardless of rate limits once the relayer starts.",
+	RunE:  ReleaseCmd,
+	Args: func(cmd *cobra.Command, args []string) error {
+		return ValidateSelectionFlags(cmd, args)
+	},
+}
+
+func init() {
+	BindSelectionFlags(releaseCmd)
+}
+
+func ReleaseCmd(cmd *cobra.Command, args []string) error {
+	msgs, err := selectedTransfers()
+	if err != nil {
+		return err
+	}
+
+	for _, hm := range msgs {
+		err = rateLimitStore.ReleaseMessage(hm.Message)
+		if err != nil {
+			return err
+		}
+		err = queueStore.Enqueue(hm.Message)
+		if err != nil {
+			return err
+		}
+		err = rateLimitStore.RemoveHeldMessage(hm.Message)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Transfer from %v with nonce %v to %v released\n", hm.Message.Source, hm.Message.DepositNonce, hm.Message.Destination)
+	}
+	return nil
+}
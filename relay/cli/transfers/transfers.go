@@ -0,0 +1,37 @@
+package transfers
+
+import (
+	"github.com/mpetrun5/diplomski-projekt/flags"
+	"github.com/mpetrun5/diplomski-projekt/lvldb"
+	"github.com/mpetrun5/diplomski-projekt/store"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var TransfersCmd = &cobra.Command{
+	Use:   "transfers",
+	Short: "Set of commands for managing transfers held by rate limits",
+	Long: "Set of commands for listing, releasing and rejecting transfers held because they exceeded a rate limit. " +
+		"The store can be opened by a single process so the relayer has to be stopped while running these commands. " +
+		"Released transfers are delivered once the relayer is started again.",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		var err error
+		db, err = lvldb.NewLvlDB(viper.GetString(flags.BlockstoreFlagName))
+		if err != nil {
+			return err
+		}
+		rateLimitStore = store.NewRateLimitStore(db)
+		deadLetterStore = store.NewDeadLetterStore(db)
+		queueStore = store.NewMessageQueueStore(db)
+		return nil
+	},
+	PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
+		return db.Close()
+	},
+}
+
+func init() {
+	TransfersCmd.AddCommand(listCmd)
+	TransfersCmd.AddCommand(releaseCmd)
+	TransfersCmd.AddCommand(rejectCmd)
+}
@@ -0,0 +1,44 @@
+package transfers
+
+import (
+	"errors"
+
+	"github.com/mpetrun5/diplomski-projekt/chains/evm/cli/flags"
+	"github.com/mpetrun5/diplomski-projekt/store"
+	"github.com/spf13/cobra"
+)
+
+// BindSelectionFlags binds flags used to select a single held transfer or
+// all held transfers for a destination domain
+func BindSelectionFlags(cmd *cobra.Command) {
+	cmd.Flags().Uint8Var(&Destination, "destination", 0, "Destination domain ID")
+	cmd.Flags().Uint8Var(&Source, "source", 0, "Source domain ID")
+	cmd.Flags().Uint64Var(&Nonce, "nonce", 0, "Deposit nonce")
+	cmd.Flags().BoolVar(&All, "all", false, "Select all held transfers for the destination domain")
+	flags.MarkFlagsAsRequired(cmd, "destination")
+}
+
+func ValidateSelectionFlags(cmd *cobra.Command, args []string) error {
+	if All {
+		if cmd.Flags().Changed("source") || cmd.Flags().Changed("nonce") {
+			return errors.New("--all can not be combined with --source and --nonce")
+		}
+		return nil
+	}
+	if !cmd.Flags().Changed("source") || !cmd.Flags().Changed("nonce") {
+		return errors.New("either --all or both --source and --nonce have to be provided")
+	}
+	return nil
+}
+
+func selectedTransfers() ([]*store.HeldMessage, error) {
+	if All {
+		return rateLimitStore.HeldMessages(Destination)
+	}
+
+	hm, err := rateLimitStore.GetHeldMessage(Destination, Source, Nonce)
+	if err != nil {
+		return nil, err
+	}
+	return []*store.HeldMessage{hm}, nil
+}
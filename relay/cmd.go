@@ -2,6 +2,10 @@ package bridge
 
 import (
 	evmCLI "github.com/mpetrun5/diplomski-projekt/chains/evm/cli"
+	"github.com/mpetrun5/diplomski-projekt/cli/accounts"
+	"github.com/mpetrun5/diplomski-projekt/cli/deposits"
+	"github.com/mpetrun5/diplomski-projekt/cli/messages"
+	"github.com/mpetrun5/diplomski-projekt/cli/transfers"
 	"github.com/mpetrun5/diplomski-projekt/flags"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
@@ -29,7 +33,7 @@ func init() {
 }
 
 func Execute() {
-	rootCMD.AddCommand(runCMD, evmCLI.EvmRootCLI)
+	rootCMD.AddCommand(runCMD, evmCLI.EvmRootCLI, accounts.AccountsCmd, messages.MessagesCmd, transfers.TransfersCmd, deposits.DepositsCmd)
 	if err := rootCMD.Execute(); err != nil {
 		log.Fatal().Err(err).Msg("failed to execute root cmd")
 	}
@@ -11,6 +11,10 @@ type GeneralChainConfig struct {
 	Name           string `mapstructure:"name"`
 	Id             *uint8 `mapstructure:"id"`
 	Endpoint       string `mapstructure:"endpoint"`
+	From           string `mapstructure:"from"`
+	RemoteSigner   string `mapstructure:"remoteSigner"`
+	KeystorePath   string
+	Insecure       bool
 	BlockstorePath string
 	FreshStart     bool
 	LatestBlock    bool
@@ -26,11 +30,23 @@ func (c *GeneralChainConfig) Validate() error {
 	if c.Name == "" {
 		return fmt.Errorf("required field chain.Name empty for chain %v", *c.Id)
 	}
+	if c.From == "" {
+		return fmt.Errorf("required field chain.From empty for chain %v", *c.Id)
+	}
+	// test keys are loaded into relayer memory, which a remote signer is meant to avoid
+	if c.RemoteSigner != "" && c.Insecure {
+		return fmt.Errorf("chain.RemoteSigner can not be used with --%s for chain %v", flags.TestKeyFlagName, *c.Id)
+	}
 	return nil
 }
 
 func (c *GeneralChainConfig) ParseFlags() {
+	c.KeystorePath = viper.GetString(flags.KeystoreFlagName)
 	c.BlockstorePath = viper.GetString(flags.BlockstoreFlagName)
 	c.FreshStart = viper.GetBool(flags.FreshStartFlagName)
 	c.LatestBlock = viper.GetBool(flags.LatestBlockFlagName)
+	if key := viper.GetString(flags.TestKeyFlagName); key != "" {
+		c.From = key
+		c.Insecure = true
+	}
 }
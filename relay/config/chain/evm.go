@@ -3,22 +3,55 @@ package chain
 import (
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/mitchellh/mapstructure"
 )
 
+const (
+	DefaultBlockConfirmations = 3
+	DefaultBlockRetryInterval = 10
+	DefaultBlockInterval      = 100
+)
+
+// block tags the listener can follow instead of the latest block
+const (
+	LatestBlockTag    = "latest"
+	SafeBlockTag      = "safe"
+	FinalizedBlockTag = "finalized"
+)
+
 type EVMConfig struct {
 	GeneralChainConfig GeneralChainConfig
 	Bridge             string
 	Erc20Handler       string
+	Erc721Handler      string
+	Erc1155Handler     string
+	NativeHandler      string
+	GenericHandler     string
 	StartBlock         *big.Int
+	Subscribe          bool
+	BlockConfirmations *big.Int
+	BlockRetryInterval time.Duration
+	BlockInterval      *big.Int
+	BlockTag           string
 }
 
 type RawEVMConfig struct {
 	GeneralChainConfig `mapstructure:",squash"`
 	Bridge             string `mapstructure:"bridge"`
 	Erc20Handler       string `mapstructure:"erc20Handler"`
+	Erc721Handler      string `mapstructure:"erc721Handler"`
+	Erc1155Handler     string `mapstructure:"erc1155Handler"`
+	NativeHandler      string `mapstructure:"nativeHandler"`
+	GenericHandler     string `mapstructure:"genericHandler"`
 	StartBlock         int64  `mapstructure:"startBlock"`
+	Subscribe          bool   `mapstructure:"subscribe"`
+	// BlockConfirmations is a pointer so that 0 confirmations can be configured explicitly
+	BlockConfirmations *int64 `mapstructure:"blockConfirmations"`
+	BlockRetryInterval int64  `mapstructure:"blockRetryInterval"`
+	BlockInterval      int64  `mapstructure:"blockInterval"`
+	BlockTag           string `mapstructure:"blockTag"`
 }
 
 func (c *RawEVMConfig) Validate() error {
@@ -28,6 +61,20 @@ func (c *RawEVMConfig) Validate() error {
 	if c.Bridge == "" {
 		return fmt.Errorf("required field chain.Bridge empty for chain %v", *c.Id)
 	}
+	if c.BlockConfirmations != nil && *c.BlockConfirmations < 0 {
+		return fmt.Errorf("field chain.BlockConfirmations can not be negative for chain %v", *c.Id)
+	}
+	if c.BlockRetryInterval < 0 {
+		return fmt.Errorf("field chain.BlockRetryInterval can not be negative for chain %v", *c.Id)
+	}
+	if c.BlockInterval < 0 {
+		return fmt.Errorf("field chain.BlockInterval can not be negative for chain %v", *c.Id)
+	}
+	switch c.BlockTag {
+	case "", LatestBlockTag, SafeBlockTag, FinalizedBlockTag:
+	default:
+		return fmt.Errorf("unsupported chain.BlockTag %s for chain %v", c.BlockTag, *c.Id)
+	}
 	return nil
 }
 
@@ -38,17 +85,40 @@ func NewEVMConfig(chainConfig map[string]interface{}) (*EVMConfig, error) {
 		return nil, err
 	}
 
+	c.GeneralChainConfig.ParseFlags()
 	err = c.Validate()
 	if err != nil {
 		return nil, err
 	}
 
-	c.GeneralChainConfig.ParseFlags()
 	config := &EVMConfig{
 		GeneralChainConfig: c.GeneralChainConfig,
 		Erc20Handler:       c.Erc20Handler,
+		Erc721Handler:      c.Erc721Handler,
+		Erc1155Handler:     c.Erc1155Handler,
+		NativeHandler:      c.NativeHandler,
+		GenericHandler:     c.GenericHandler,
 		Bridge:             c.Bridge,
 		StartBlock:         big.NewInt(c.StartBlock),
+		Subscribe:          c.Subscribe,
+		BlockTag:           c.BlockTag,
+	}
+
+	// blocks behind the safe or finalized head don't need additional confirmations
+	config.BlockConfirmations = big.NewInt(DefaultBlockConfirmations)
+	if c.BlockTag == SafeBlockTag || c.BlockTag == FinalizedBlockTag {
+		config.BlockConfirmations = big.NewInt(0)
+	}
+	if c.BlockConfirmations != nil {
+		config.BlockConfirmations = big.NewInt(*c.BlockConfirmations)
+	}
+	config.BlockRetryInterval = time.Duration(c.BlockRetryInterval) * time.Second
+	if c.BlockRetryInterval == 0 {
+		config.BlockRetryInterval = DefaultBlockRetryInterval * time.Second
+	}
+	config.BlockInterval = big.NewInt(c.BlockInterval)
+	if c.BlockInterval == 0 {
+		config.BlockInterval = big.NewInt(DefaultBlockInterval)
 	}
 
 	return config, nil
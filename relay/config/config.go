@@ -2,16 +2,51 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
+const (
+	DefaultMetricsPort     = "9001"
+	DefaultShutdownTimeout = 30
+	DefaultHealthPort      = "9002"
+	DefaultStallThreshold  = 300
+	DefaultWorkers         = 1
+	DefaultQueueSize       = 100
+)
+
 type Config struct {
-	ChainConfigs []map[string]interface{}
+	RelayerConfig RelayerConfig
+	ChainConfigs  []map[string]interface{}
+}
+
+type RelayerConfig struct {
+	MetricsPort     string
+	ShutdownTimeout time.Duration
+	HealthPort      string
+	StallThreshold  time.Duration
+	Workers         int
+	QueueSize       int
+	Processors      []map[string]interface{}
+	RateLimits      []map[string]interface{}
 }
 
 type RawConfig struct {
-	ChainConfigs []map[string]interface{} `mapstructure:"chains" json:"chains"`
+	RelayerConfig RawRelayerConfig         `mapstructure:"relayer" json:"relayer"`
+	ChainConfigs  []map[string]interface{} `mapstructure:"chains" json:"chains"`
+}
+
+type RawRelayerConfig struct {
+	MetricsPort     string `mapstructure:"metricsPort" json:"metricsPort"`
+	ShutdownTimeout int64  `mapstructure:"shutdownTimeout" json:"shutdownTimeout"`
+	HealthPort      string `mapstructure:"healthPort" json:"healthPort"`
+	StallThreshold  int64  `mapstructure:"stallThreshold" json:"stallThreshold"`
+	Workers         int    `mapstructure:"workers" json:"workers"`
+	QueueSize       int    `mapstructure:"queueSize" json:"queueSize"`
+
+	Processors []map[string]interface{} `mapstructure:"processors" json:"processors"`
+	RateLimits []map[string]interface{} `mapstructure:"rateLimits" json:"rateLimits"`
 }
 
 // GetConfig reads config from file, validates it and parses
@@ -39,6 +74,40 @@ func GetConfig(path string) (Config, error) {
 		}
 	}
 
+	config.RelayerConfig = NewRelayerConfig(rawConfig.RelayerConfig)
 	config.ChainConfigs = rawConfig.ChainConfigs
 	return config, nil
 }
+
+// NewRelayerConfig parses raw relayer config and applies defaults for unset fields
+func NewRelayerConfig(rawConfig RawRelayerConfig) RelayerConfig {
+	config := RelayerConfig{
+		MetricsPort: rawConfig.MetricsPort,
+		HealthPort:  rawConfig.HealthPort,
+		Workers:     rawConfig.Workers,
+		QueueSize:   rawConfig.QueueSize,
+		Processors:  rawConfig.Processors,
+		RateLimits:  rawConfig.RateLimits,
+	}
+	if config.MetricsPort == "" {
+		config.MetricsPort = DefaultMetricsPort
+	}
+	config.ShutdownTimeout = time.Duration(rawConfig.ShutdownTimeout) * time.Second
+	if rawConfig.ShutdownTimeout == 0 {
+		config.ShutdownTimeout = DefaultShutdownTimeout * time.Second
+	}
+	if config.HealthPort == "" {
+		config.HealthPort = DefaultHealthPort
+	}
+	config.StallThreshold = time.Duration(rawConfig.StallThreshold) * time.Second
+	if rawConfig.StallThreshold == 0 {
+		config.StallThreshold = DefaultStallThreshold * time.Second
+	}
+	if config.Workers <= 0 {
+		config.Workers = DefaultWorkers
+	}
+	if config.QueueSize <= 0 {
+		config.QueueSize = DefaultQueueSize
+	}
+	return config
+}
@@ -0,0 +1,44 @@
+package crypto
+
+import (
+	"github.com/mpetrun5/diplomski-projekt/crypto/secp256k1"
+)
+
+// Names of the predetermined test keys and their addresses:
+//
+//	alice   0xff93B45308FD417dF303D6515aB04D9e89a750Ca
+//	bob     0x8e0a907331554AF72563Bd8D43051C2E64Be5d35
+//	charlie 0x24962717f8fA5BA3b931bACaF9ac03924EB475a0
+//	dave    0x148FfB2074A9e59eD58142822b3eB3fcBffb0cd7
+//	eve     0x4CEEf6139f00F9F4535Ad19640Ff7A0137708485
+const (
+	AliceKey   = "alice"
+	BobKey     = "bob"
+	CharlieKey = "charlie"
+	DaveKey    = "dave"
+	EveKey     = "eve"
+)
+
+// Keys lists names of all keys found in the test keyring
+var Keys = []string{AliceKey, BobKey, CharlieKey, DaveKey, EveKey}
+
+// TestKeyRing holds deterministic secp256k1 keypairs meant for local devnets only.
+// Private key of every keypair is its name left padded with zeros to 32 bytes.
+var TestKeyRing = createTestKeyRing()
+
+func createTestKeyRing() map[string]*secp256k1.Keypair {
+	ring := make(map[string]*secp256k1.Keypair, len(Keys))
+	for _, name := range Keys {
+		kp, err := secp256k1.NewKeypairFromPrivateKey(padWithZeros([]byte(name), secp256k1.PrivateKeyLength))
+		if err != nil {
+			panic(err)
+		}
+		ring[name] = kp
+	}
+	return ring
+}
+
+func padWithZeros(key []byte, targetLength int) []byte {
+	res := make([]byte, targetLength-len(key))
+	return append(res, key...)
+}
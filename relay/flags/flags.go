@@ -19,6 +19,9 @@ func BindFlags(rootCMD *cobra.Command) {
 	rootCMD.PersistentFlags().String(ConfigFlagName, ".", "Path to JSON configuration file")
 	_ = viper.BindPFlag(ConfigFlagName, rootCMD.PersistentFlags().Lookup(ConfigFlagName))
 
+	rootCMD.PersistentFlags().String(KeystoreFlagName, "./keys", "Path to keystore directory")
+	_ = viper.BindPFlag(KeystoreFlagName, rootCMD.PersistentFlags().Lookup(KeystoreFlagName))
+
 	rootCMD.PersistentFlags().String(BlockstoreFlagName, "./lvldbdata", "Specify path for blockstore")
 	_ = viper.BindPFlag(BlockstoreFlagName, rootCMD.PersistentFlags().Lookup(BlockstoreFlagName))
 
@@ -0,0 +1,117 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ChainStatus describes health of a single relayed chain
+type ChainStatus struct {
+	DomainID             uint8    `json:"domainId"`
+	LastProcessedBlock   *big.Int `json:"lastProcessedBlock"`
+	Head                 *big.Int `json:"head"`
+	SecondsSinceProgress float64  `json:"secondsSinceProgress"`
+	RPCReachable         bool     `json:"rpcReachable"`
+	HasRelayerRole       bool     `json:"hasRelayerRole"`
+	Stalled              bool     `json:"stalled"`
+	Errors               []string `json:"errors,omitempty"`
+}
+
+type Chain interface {
+	HealthStatus() ChainStatus
+}
+
+type Response struct {
+	Status string        `json:"status"`
+	Chains []ChainStatus `json:"chains"`
+}
+
+type HealthServer struct {
+	chains         []Chain
+	stallThreshold time.Duration
+	server         *http.Server
+}
+
+// NewHealthServer creates a server exposing /health and /ready endpoints on the provided port
+func NewHealthServer(chains []Chain, stallThreshold time.Duration, port string) *HealthServer {
+	s := &HealthServer{chains: chains, stallThreshold: stallThreshold}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", s.healthHandler)
+	mux.HandleFunc("/ready", s.readyHandler)
+	s.server = &http.Server{Addr: fmt.Sprintf(":%s", port), Handler: mux}
+	return s
+}
+
+// Serve serves health checks until the server is shut down
+func (s *HealthServer) Serve() error {
+	log.Info().Msgf("Serving health checks on %s", s.server.Addr)
+	err := s.server.ListenAndServe()
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("health server failed: %w", err)
+	}
+	return nil
+}
+
+// Shutdown stops the server, waiting for active requests until ctx is done
+func (s *HealthServer) Shutdown(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
+
+// healthHandler reports the relayer as unhealthy if any chain made no progress for longer than
+// the stall threshold
+func (s *HealthServer) healthHandler(w http.ResponseWriter, r *http.Request) {
+	statuses := s.chainStatuses()
+	healthy := true
+	for _, status := range statuses {
+		if status.Stalled {
+			healthy = false
+		}
+	}
+	writeResponse(w, healthy, statuses)
+}
+
+// readyHandler reports the relayer as ready if all chains are reachable, progressing
+// and the relayer account is allowed to vote on every bridge
+func (s *HealthServer) readyHandler(w http.ResponseWriter, r *http.Request) {
+	statuses := s.chainStatuses()
+	ready := true
+	for _, status := range statuses {
+		if status.Stalled || !status.RPCReachable || !status.HasRelayerRole {
+			ready = false
+		}
+	}
+	writeResponse(w, ready, statuses)
+}
+
+func (s *HealthServer) chainStatuses() []ChainStatus {
+	statuses := make([]ChainStatus, len(s.chains))
+	for i, c := range s.chains {
+		status := c.HealthStatus()
+		status.Stalled = status.SecondsSinceProgress > s.stallThreshold.Seconds()
+		statuses[i] = status
+	}
+	return statuses
+}
+
+func writeResponse(w http.ResponseWriter, ok bool, statuses []ChainStatus) {
+	res := Response{Status: "ok", Chains: statuses}
+	code := http.StatusOK
+	if !ok {
+		res.Status = "unavailable"
+		code = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	err := json.NewEncoder(w).Encode(res)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed writing health response")
+	}
+}
@@ -0,0 +1,220 @@
+package keystore
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	ethkeystore "github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	"github.com/mpetrun5/diplomski-projekt/crypto"
+	"github.com/mpetrun5/diplomski-projekt/crypto/secp256k1"
+)
+
+const (
+	// EnvPassword is the environment variable holding the keystore password
+	EnvPassword = "KEYSTORE_PASSWORD"
+	// EnvPasswordFile is the environment variable holding the path of a file containing the keystore password
+	EnvPasswordFile = "KEYSTORE_PASSWORD_FILE"
+)
+
+type keyFileAddress struct {
+	Address string `json:"address"`
+}
+
+// KeypairFromAddress finds the encrypted keystore file for the provided address inside
+// the keystore directory and decrypts it with the password resolved by GetPassword.
+// If insecure is set addr is treated as the name of a predetermined test key.
+func KeypairFromAddress(addr string, path string, insecure bool) (*secp256k1.Keypair, error) {
+	if insecure {
+		return insecureKeypairFromName(addr)
+	}
+
+	if !common.IsHexAddress(addr) {
+		return nil, fmt.Errorf("invalid address %s", addr)
+	}
+
+	keyJSON, err := FindKeyFile(common.HexToAddress(addr), path)
+	if err != nil {
+		return nil, err
+	}
+
+	password, err := GetPassword(fmt.Sprintf("Enter password for key %s:", addr))
+	if err != nil {
+		return nil, err
+	}
+
+	return KeypairFromJSON(keyJSON, password)
+}
+
+func insecureKeypairFromName(name string) (*secp256k1.Keypair, error) {
+	kp, ok := crypto.TestKeyRing[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("no test key named %s, available keys are %v", name, crypto.Keys)
+	}
+
+	return kp, nil
+}
+
+// KeypairFromJSON decrypts JSON v3 keystore contents into a keypair
+func KeypairFromJSON(keyJSON []byte, password string) (*secp256k1.Keypair, error) {
+	key, err := ethkeystore.DecryptKey(keyJSON, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed decrypting key: %w", err)
+	}
+
+	return secp256k1.NewKeypair(*key.PrivateKey), nil
+}
+
+// FindKeyFile returns contents of the keystore file inside the directory that holds
+// the key for the provided address
+func FindKeyFile(addr common.Address, path string) ([]byte, error) {
+	keys, err := ListKeys(path)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, k := range keys {
+		if k.Address == addr {
+			return ioutil.ReadFile(k.Path)
+		}
+	}
+
+	return nil, fmt.Errorf("key for address %s not found in keystore %s", addr.Hex(), path)
+}
+
+func addressFromJSON(keyJSON []byte) (common.Address, error) {
+	var k keyFileAddress
+	err := json.Unmarshal(keyJSON, &k)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if !common.IsHexAddress(k.Address) {
+		return common.Address{}, fmt.Errorf("invalid key file address %s", k.Address)
+	}
+
+	return common.HexToAddress(k.Address), nil
+}
+
+// GetPassword resolves keystore password from the environment variable, password file
+// or prompts the user for it if neither is set
+func GetPassword(msg string) (string, error) {
+	if password, ok := os.LookupEnv(EnvPassword); ok {
+		return password, nil
+	}
+
+	if passwordFile := os.Getenv(EnvPasswordFile); passwordFile != "" {
+		password, err := ioutil.ReadFile(passwordFile)
+		if err != nil {
+			return "", fmt.Errorf("failed reading password file: %w", err)
+		}
+		return strings.TrimRight(string(password), "\r\n"), nil
+	}
+
+	return PromptPassword(msg)
+}
+
+// GetNewPassword resolves password for a new keystore file. When prompting, the user
+// has to confirm the password
+func GetNewPassword(msg string) (string, error) {
+	if _, ok := os.LookupEnv(EnvPassword); ok {
+		return GetPassword(msg)
+	}
+	if os.Getenv(EnvPasswordFile) != "" {
+		return GetPassword(msg)
+	}
+
+	password, err := PromptPassword(msg)
+	if err != nil {
+		return "", err
+	}
+	confirmation, err := PromptPassword("Confirm password:")
+	if err != nil {
+		return "", err
+	}
+	if password != confirmation {
+		return "", errors.New("passwords do not match")
+	}
+
+	return password, nil
+}
+
+// StoreKeypair encrypts the keypair into a JSON v3 keystore file inside the keystore
+// directory and returns the path of the created file
+func StoreKeypair(kp *secp256k1.Keypair, path string, password string) (string, error) {
+	if _, err := FindKeyFile(kp.CommonAddress(), path); err == nil {
+		return "", fmt.Errorf("key for address %s already exists in keystore %s", kp.Address(), path)
+	}
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return "", err
+	}
+	key := &ethkeystore.Key{
+		Id:         id,
+		Address:    kp.CommonAddress(),
+		PrivateKey: kp.PrivateKey(),
+	}
+	keyJSON, err := ethkeystore.EncryptKey(key, password, ethkeystore.StandardScryptN, ethkeystore.StandardScryptP)
+	if err != nil {
+		return "", err
+	}
+
+	err = os.MkdirAll(path, 0700)
+	if err != nil {
+		return "", err
+	}
+	file := filepath.Join(path, keyFileName(kp.CommonAddress()))
+	err = ioutil.WriteFile(file, keyJSON, 0600)
+	if err != nil {
+		return "", err
+	}
+
+	return file, nil
+}
+
+// keyFileName follows the go-ethereum keystore naming: UTC--<created_at UTC ISO8601>--<address hex>
+func keyFileName(addr common.Address) string {
+	ts := time.Now().UTC().Format("2006-01-02T15-04-05.000000000Z")
+	return fmt.Sprintf("UTC--%s--%s", ts, hex.EncodeToString(addr[:]))
+}
+
+type KeyFile struct {
+	Address common.Address
+	Path    string
+}
+
+// ListKeys returns addresses and paths of all keystore files found in the keystore directory
+func ListKeys(path string) ([]KeyFile, error) {
+	files, err := ioutil.ReadDir(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading keystore directory %s: %w", path, err)
+	}
+
+	keys := make([]KeyFile, 0)
+	for _, f := range files {
+		if f.IsDir() || strings.HasPrefix(f.Name(), ".") {
+			continue
+		}
+
+		file := filepath.Join(path, f.Name())
+		keyJSON, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		addr, err := addressFromJSON(keyJSON)
+		if err != nil {
+			continue
+		}
+		keys = append(keys, KeyFile{Address: addr, Path: file})
+	}
+
+	return keys, nil
+}
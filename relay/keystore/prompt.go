@@ -0,0 +1,49 @@
+package keystore
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// PromptPassword prompts the user for a password on the terminal
+func PromptPassword(msg string) (string, error) {
+	fmt.Fprint(os.Stderr, msg+" ")
+	password, err := readPassword(os.Stdin)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("failed reading password: %w", err)
+	}
+
+	return password, nil
+}
+
+var (
+	readersLock sync.Mutex
+	// readers buffer input per file for the lifetime of the process. A reader buffers past
+	// the line it returns, so with piped input a new reader per prompt would lose the following lines.
+	readers = make(map[*os.File]*bufio.Reader)
+)
+
+func reader(f *os.File) *bufio.Reader {
+	readersLock.Lock()
+	defer readersLock.Unlock()
+
+	r, ok := readers[f]
+	if !ok {
+		r = bufio.NewReader(f)
+		readers[f] = r
+	}
+	return r
+}
+
+func readLine(f *os.File) (string, error) {
+	line, err := reader(f).ReadString('\n')
+	if err != nil && line == "" {
+		return "", err
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
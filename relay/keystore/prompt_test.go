@@ -0,0 +1,30 @@
+package keystore
+
+import (
+	"os"
+	"testing"
+)
+
+func TestReadPassword_ReadsConsecutiveLinesFromPipe(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	_, err = w.WriteString("password\npassword confirmation\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+
+	for _, expected := range []string{"password", "password confirmation"} {
+		line, err := readPassword(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if line != expected {
+			t.Fatalf("expected %q, got %q", expected, line)
+		}
+	}
+}
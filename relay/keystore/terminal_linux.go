@@ -0,0 +1,32 @@
+//go:build linux
+// +build linux
+
+package keystore
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// readPassword reads a line from the terminal with echo disabled
+func readPassword(f *os.File) (string, error) {
+	fd := int(f.Fd())
+	termios, err := unix.IoctlGetTermios(fd, unix.TCGETS)
+	if err != nil {
+		// not a terminal, input is most likely piped
+		return readLine(f)
+	}
+
+	noEcho := *termios
+	noEcho.Lflag &^= unix.ECHO
+	noEcho.Lflag |= unix.ICANON | unix.ISIG
+	if err := unix.IoctlSetTermios(fd, unix.TCSETS, &noEcho); err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = unix.IoctlSetTermios(fd, unix.TCSETS, termios)
+	}()
+
+	return readLine(f)
+}
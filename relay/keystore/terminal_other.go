@@ -0,0 +1,13 @@
+//go:build !linux
+// +build !linux
+
+package keystore
+
+import (
+	"os"
+)
+
+// readPassword reads a line from the terminal. Echo is not disabled on this platform
+func readPassword(f *os.File) (string, error) {
+	return readLine(f)
+}
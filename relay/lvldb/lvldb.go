@@ -3,6 +3,7 @@ package lvldb
 import (
 	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
 type LVLDB struct {
@@ -25,6 +26,22 @@ func (db *LVLDB) SetByKey(key []byte, value []byte) error {
 	return db.db.Put(key, value, nil)
 }
 
+func (db *LVLDB) DeleteByKey(key []byte) error {
+	return db.db.Delete(key, nil)
+}
+
+// GetByPrefix returns values of all keys starting with prefix ordered by key
+func (db *LVLDB) GetByPrefix(prefix []byte) ([][]byte, error) {
+	iter := db.db.NewIterator(util.BytesPrefix(prefix), nil)
+	defer iter.Release()
+
+	values := make([][]byte, 0)
+	for iter.Next() {
+		values = append(values, append([]byte{}, iter.Value()...))
+	}
+	return values, iter.Error()
+}
+
 func (db *LVLDB) Close() error {
 	return db.db.Close()
 }
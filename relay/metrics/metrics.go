@@ -0,0 +1,193 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/mpetrun5/diplomski-projekt/relayer/message"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rs/zerolog/log"
+)
+
+const namespace = "relayer"
+
+var balancePollInterval = 60 * time.Second
+
+type BalanceReader interface {
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+}
+
+// RelayerMetrics collects relayer prometheus metrics
+type RelayerMetrics struct {
+	depositEvents    *prometheus.CounterVec
+	votesSent        *prometheus.CounterVec
+	voteFailures     *prometheus.CounterVec
+	headLag          *prometheus.GaugeVec
+	blockstoreHeight *prometheus.GaugeVec
+	relayerBalance   *prometheus.GaugeVec
+	queueDepth       *prometheus.GaugeVec
+	lastVotedBlock   *prometheus.GaugeVec
+	reorgs           *prometheus.CounterVec
+}
+
+// NewRelayerMetrics creates relayer metrics and registers them with the provided registerer
+func NewRelayerMetrics(registerer prometheus.Registerer) *RelayerMetrics {
+	m := &RelayerMetrics{
+		depositEvents: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "deposit_events_total",
+			Help:      "Number of deposit messages seen per source, destination and resource ID",
+		}, []string{"source", "destination", "resource_id"}),
+		votesSent: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "votes_sent_total",
+			Help:      "Number of successfully sent proposal votes",
+		}, []string{"source", "destination"}),
+		voteFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "vote_failures_total",
+			Help:      "Number of failed proposal votes",
+		}, []string{"source", "destination"}),
+		headLag: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "listener_head_lag_blocks",
+			Help:      "Number of blocks the listener is behind the chain head",
+		}, []string{"domain"}),
+		blockstoreHeight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "blockstore_height",
+			Help:      "Latest block stored in the blockstore",
+		}, []string{"domain"}),
+		relayerBalance: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "account_balance_wei",
+			Help:      "Balance of the relayer account in wei",
+		}, []string{"domain", "address"}),
+		queueDepth: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "queue_depth",
+			Help:      "Number of messages waiting for delivery per destination",
+		}, []string{"destination"}),
+		lastVotedBlock: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "last_voted_source_block",
+			Help:      "Source chain block of the latest deposit that was voted on",
+		}, []string{"source", "destination"}),
+		reorgs: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reorgs_total",
+			Help:      "Number of chain reorganizations detected by the listener",
+		}, []string{"domain"}),
+	}
+
+	registerer.MustRegister(
+		m.depositEvents,
+		m.votesSent,
+		m.voteFailures,
+		m.headLag,
+		m.blockstoreHeight,
+		m.relayerBalance,
+		m.queueDepth,
+		m.lastVotedBlock,
+		m.reorgs,
+	)
+	return m
+}
+
+func (m *RelayerMetrics) TrackDepositMessage(msg *message.Message) {
+	m.depositEvents.WithLabelValues(domainLabel(msg.Source), domainLabel(msg.Destination), hexutil.Encode(msg.ResourceId[:])).Inc()
+}
+
+func (m *RelayerMetrics) TrackVoteSent(msg *message.Message) {
+	m.votesSent.WithLabelValues(domainLabel(msg.Source), domainLabel(msg.Destination)).Inc()
+	m.lastVotedBlock.WithLabelValues(domainLabel(msg.Source), domainLabel(msg.Destination)).Set(float64(msg.BlockNumber))
+}
+
+func (m *RelayerMetrics) TrackVoteFailure(msg *message.Message) {
+	m.voteFailures.WithLabelValues(domainLabel(msg.Source), domainLabel(msg.Destination)).Inc()
+}
+
+func (m *RelayerMetrics) TrackHeadLag(domainID uint8, lag *big.Int) {
+	m.headLag.WithLabelValues(domainLabel(domainID)).Set(toFloat(lag))
+}
+
+func (m *RelayerMetrics) TrackBlockstoreHeight(domainID uint8, block *big.Int) {
+	m.blockstoreHeight.WithLabelValues(domainLabel(domainID)).Set(toFloat(block))
+}
+
+func (m *RelayerMetrics) TrackRelayerBalance(domainID uint8, address common.Address, balance *big.Int) {
+	m.relayerBalance.WithLabelValues(domainLabel(domainID), address.Hex()).Set(toFloat(balance))
+}
+
+func (m *RelayerMetrics) TrackQueueDepth(domainID uint8, depth int) {
+	m.queueDepth.WithLabelValues(domainLabel(domainID)).Set(float64(depth))
+}
+
+func (m *RelayerMetrics) TrackReorg(domainID uint8) {
+	m.reorgs.WithLabelValues(domainLabel(domainID)).Inc()
+}
+
+// PollRelayerBalance periodically tracks relayer account balance until stop is closed
+func (m *RelayerMetrics) PollRelayerBalance(stop <-chan struct{}, client BalanceReader, domainID uint8, address common.Address) {
+	for {
+		balance, err := client.BalanceAt(context.Background(), address, nil)
+		if err != nil {
+			log.Warn().Err(err).Msgf("Failed fetching relayer balance on domain %v", domainID)
+		} else {
+			m.TrackRelayerBalance(domainID, address, balance)
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-time.After(balancePollInterval):
+			continue
+		}
+	}
+}
+
+// Server exposes metrics from the default prometheus registry over HTTP /metrics endpoint
+type Server struct {
+	server *http.Server
+}
+
+// NewServer creates a metrics server listening on the provided port
+func NewServer(port string) *Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	return &Server{
+		server: &http.Server{Addr: fmt.Sprintf(":%s", port), Handler: mux},
+	}
+}
+
+// Serve serves metrics until the server is shut down
+func (s *Server) Serve() error {
+	log.Info().Msgf("Serving metrics on %s", s.server.Addr)
+	err := s.server.ListenAndServe()
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("metrics server failed: %w", err)
+	}
+	return nil
+}
+
+// Shutdown stops the server, waiting for active requests until ctx is done
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
+
+func domainLabel(domainID uint8) string {
+	return strconv.Itoa(int(domainID))
+}
+
+func toFloat(v *big.Int) float64 {
+	f, _ := new(big.Float).SetInt(v).Float64()
+	return f
+}
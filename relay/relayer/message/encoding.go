@@ -0,0 +1,166 @@
+package message
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// EncodingVersion is the version of the message encoding. It has to be increased
+// whenever the encoded format changes so that older messages can still be decoded.
+const EncodingVersion = 2
+
+// minEncodingVersion is the oldest message encoding that can still be decoded.
+// Version 1 messages don't contain the source deposit log position.
+const minEncodingVersion = 1
+
+type encodedMessage struct {
+	Version      int             `json:"version"`
+	Source       uint8           `json:"source"`
+	Destination  uint8           `json:"destination"`
+	DepositNonce uint64          `json:"depositNonce"`
+	ResourceID   hexutil.Bytes   `json:"resourceId"`
+	Type         TransferType    `json:"type"`
+	Payload      json.RawMessage `json:"payload"`
+	BlockNumber  uint64          `json:"blockNumber"`
+	TxHash       common.Hash     `json:"txHash"`
+	Index        uint            `json:"index"`
+}
+
+type encodedFungiblePayload struct {
+	Amount    *big.Int      `json:"amount"`
+	Recipient hexutil.Bytes `json:"recipient"`
+}
+
+type encodedNonFungiblePayload struct {
+	TokenID   *big.Int      `json:"tokenId"`
+	Recipient hexutil.Bytes `json:"recipient"`
+	Metadata  hexutil.Bytes `json:"metadata"`
+}
+
+type encodedSemiFungiblePayload struct {
+	TokenIDs     []*big.Int    `json:"tokenIds"`
+	Amounts      []*big.Int    `json:"amounts"`
+	Recipient    hexutil.Bytes `json:"recipient"`
+	TransferData hexutil.Bytes `json:"transferData"`
+}
+
+type encodedGenericPayload struct {
+	Metadata hexutil.Bytes `json:"metadata"`
+}
+
+// Encode serializes the message into versioned JSON
+func Encode(m *Message) ([]byte, error) {
+	return json.Marshal(m)
+}
+
+// Decode deserializes the message from versioned JSON
+func Decode(data []byte) (*Message, error) {
+	m := &Message{}
+	err := json.Unmarshal(data, m)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m *Message) MarshalJSON() ([]byte, error) {
+	var payload interface{}
+	switch p := m.Payload.(type) {
+	case *FungibleTransferPayload:
+		payload = encodedFungiblePayload{Amount: p.Amount, Recipient: p.Recipient}
+	case *NonFungibleTransferPayload:
+		payload = encodedNonFungiblePayload{TokenID: p.TokenID, Recipient: p.Recipient, Metadata: p.Metadata}
+	case *SemiFungibleTransferPayload:
+		payload = encodedSemiFungiblePayload{TokenIDs: p.TokenIDs, Amounts: p.Amounts, Recipient: p.Recipient, TransferData: p.TransferData}
+	case *GenericTransferPayload:
+		payload = encodedGenericPayload{Metadata: p.Metadata}
+	case nil:
+		payload = nil
+	default:
+		return nil, fmt.Errorf("unsupported payload type %T", m.Payload)
+	}
+	if m.Payload != nil && m.Payload.TransferType() != m.Type {
+		return nil, fmt.Errorf("payload type %s does not match message type %s", m.Payload.TransferType(), m.Type)
+	}
+
+	encodedPayload, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(encodedMessage{
+		Version:      EncodingVersion,
+		Source:       m.Source,
+		Destination:  m.Destination,
+		DepositNonce: m.DepositNonce,
+		ResourceID:   m.ResourceId[:],
+		Type:         m.Type,
+		Payload:      encodedPayload,
+		BlockNumber:  m.BlockNumber,
+		TxHash:       m.TxHash,
+		Index:        m.Index,
+	})
+}
+
+func (m *Message) UnmarshalJSON(data []byte) error {
+	var em encodedMessage
+	err := json.Unmarshal(data, &em)
+	if err != nil {
+		return err
+	}
+	if em.Version < minEncodingVersion || em.Version > EncodingVersion {
+		return fmt.Errorf("unsupported message encoding version %v", em.Version)
+	}
+	if len(em.ResourceID) != 32 {
+		return fmt.Errorf("invalid resource ID length %v", len(em.ResourceID))
+	}
+
+	m.Source = em.Source
+	m.Destination = em.Destination
+	m.DepositNonce = em.DepositNonce
+	copy(m.ResourceId[:], em.ResourceID)
+	m.Type = em.Type
+	m.BlockNumber = em.BlockNumber
+	m.TxHash = em.TxHash
+	m.Index = em.Index
+	m.Payload, err = decodePayload(em.Type, em.Payload)
+	return err
+}
+
+func decodePayload(transferType TransferType, data json.RawMessage) (Payload, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+
+	switch transferType {
+	case FungibleTransfer:
+		var p encodedFungiblePayload
+		if err := json.Unmarshal(data, &p); err != nil {
+			return nil, err
+		}
+		return &FungibleTransferPayload{Amount: p.Amount, Recipient: p.Recipient}, nil
+	case NonFungibleTransfer:
+		var p encodedNonFungiblePayload
+		if err := json.Unmarshal(data, &p); err != nil {
+			return nil, err
+		}
+		return &NonFungibleTransferPayload{TokenID: p.TokenID, Recipient: p.Recipient, Metadata: p.Metadata}, nil
+	case SemiFungibleTransfer:
+		var p encodedSemiFungiblePayload
+		if err := json.Unmarshal(data, &p); err != nil {
+			return nil, err
+		}
+		return &SemiFungibleTransferPayload{TokenIDs: p.TokenIDs, Amounts: p.Amounts, Recipient: p.Recipient, TransferData: p.TransferData}, nil
+	case GenericTransfer:
+		var p encodedGenericPayload
+		if err := json.Unmarshal(data, &p); err != nil {
+			return nil, err
+		}
+		return &GenericTransferPayload{Metadata: p.Metadata}, nil
+	default:
+		return nil, fmt.Errorf("unsupported transfer type %s", transferType)
+	}
+}
@@ -0,0 +1,153 @@
+package message
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var testRecipient = common.HexToAddress("0x62877dDCd49aD22f5eDfc6ac108e9a4b5D2bD88B").Bytes()
+
+func newTestMessage(transferType TransferType, payload Payload) *Message {
+	return &Message{
+		Source:       1,
+		Destination:  2,
+		DepositNonce: 3,
+		ResourceId:   common.HexToHash("0x0000000000000000000000c76fc2a3a3d1c4fc7a9f5daef7e0bb7d9b0ca9f01"),
+		Type:         transferType,
+		Payload:      payload,
+		BlockNumber:  100,
+		TxHash:       common.HexToHash("0xabcd"),
+		Index:        4,
+	}
+}
+
+// encodeWithVersion encodes the message and overrides its encoding version
+func encodeWithVersion(t *testing.T, m *Message, version int) []byte {
+	data, err := Encode(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields map[string]interface{}
+	err = json.Unmarshal(data, &fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields["version"] = version
+	data, err = json.Marshal(fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestEncoding_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  *Message
+	}{
+		{
+			name: "fungible",
+			msg: newTestMessage(FungibleTransfer, &FungibleTransferPayload{
+				Amount:    big.NewInt(1000),
+				Recipient: testRecipient,
+			}),
+		},
+		{
+			name: "non-fungible",
+			msg: newTestMessage(NonFungibleTransfer, &NonFungibleTransferPayload{
+				TokenID:   big.NewInt(42),
+				Recipient: testRecipient,
+				Metadata:  []byte("ipfs://token"),
+			}),
+		},
+		{
+			name: "semi-fungible",
+			msg: newTestMessage(SemiFungibleTransfer, &SemiFungibleTransferPayload{
+				TokenIDs:     []*big.Int{big.NewInt(1), big.NewInt(2)},
+				Amounts:      []*big.Int{big.NewInt(10), big.NewInt(20)},
+				Recipient:    testRecipient,
+				TransferData: []byte{1, 2, 3},
+			}),
+		},
+		{
+			name: "generic",
+			msg: newTestMessage(GenericTransfer, &GenericTransferPayload{
+				Metadata: []byte{0xde, 0xad, 0xbe, 0xef},
+			}),
+		},
+		{
+			name: "without payload",
+			msg:  newTestMessage(FungibleTransfer, nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := Encode(tt.msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			decoded, err := Decode(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(decoded, tt.msg) {
+				t.Fatalf("decoded message %+v differs from encoded message %+v", decoded, tt.msg)
+			}
+		})
+	}
+}
+
+func TestEncoding_Versions(t *testing.T) {
+	msg := newTestMessage(FungibleTransfer, &FungibleTransferPayload{Amount: big.NewInt(1), Recipient: testRecipient})
+
+	tests := []struct {
+		name    string
+		version int
+		wantErr bool
+	}{
+		{name: "current version", version: EncodingVersion},
+		{name: "oldest supported version", version: minEncodingVersion},
+		{name: "version older than supported", version: minEncodingVersion - 1, wantErr: true},
+		{name: "version newer than supported", version: EncodingVersion + 1, wantErr: true},
+		{name: "unknown version", version: 1000, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Decode(encodeWithVersion(t, msg, tt.version))
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "unsupported message encoding version") {
+					t.Fatalf("expected unsupported version error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestEncoding_RejectsPayloadNotMatchingType(t *testing.T) {
+	msg := newTestMessage(NonFungibleTransfer, &FungibleTransferPayload{Amount: big.NewInt(1), Recipient: testRecipient})
+
+	_, err := Encode(msg)
+	if err == nil {
+		t.Fatal("expected error for payload not matching message type")
+	}
+}
+
+func TestEncoding_RejectsUnknownTransferType(t *testing.T) {
+	data := []byte(`{"version":1,"resourceId":"0x0000000000000000000000000000000000000000000000000000000000000001","type":"Unknown","payload":{}}`)
+
+	_, err := Decode(data)
+	if err == nil || !strings.Contains(err.Error(), "unsupported transfer type") {
+		t.Fatalf("expected unsupported transfer type error, got %v", err)
+	}
+}
@@ -4,15 +4,19 @@
 package message
 
 import (
+	"fmt"
 	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
 )
 
 type TransferType string
 
 const (
-	FungibleTransfer    TransferType = "FungibleTransfer"
-	NonFungibleTransfer TransferType = "NonFungibleTransfer"
-	GenericTransfer     TransferType = "GenericTransfer"
+	FungibleTransfer     TransferType = "FungibleTransfer"
+	NonFungibleTransfer  TransferType = "NonFungibleTransfer"
+	GenericTransfer      TransferType = "GenericTransfer"
+	SemiFungibleTransfer TransferType = "SemiFungibleTransfer"
 )
 
 type ProposalStatus struct {
@@ -35,6 +39,94 @@ type Message struct {
 	Destination  uint8
 	DepositNonce uint64
 	ResourceId   [32]byte
-	Payload      []interface{}
+	Payload      Payload
 	Type         TransferType
+	// BlockNumber, TxHash and Index identify the source chain deposit log the message was created from
+	BlockNumber uint64
+	TxHash      common.Hash
+	Index       uint
+}
+
+// Payload holds transfer type specific message data
+type Payload interface {
+	TransferType() TransferType
+}
+
+// FungibleTransferPayload transfers amount of tokens to the recipient
+type FungibleTransferPayload struct {
+	Amount    *big.Int
+	Recipient []byte
+}
+
+func (p *FungibleTransferPayload) TransferType() TransferType {
+	return FungibleTransfer
+}
+
+// NonFungibleTransferPayload transfers the token with token ID and its metadata to the recipient
+type NonFungibleTransferPayload struct {
+	TokenID   *big.Int
+	Recipient []byte
+	Metadata  []byte
+}
+
+func (p *NonFungibleTransferPayload) TransferType() TransferType {
+	return NonFungibleTransfer
+}
+
+// SemiFungibleTransferPayload transfers a batch of multi-token amounts to the recipient.
+// Amounts[i] is the amount transferred of TokenIDs[i].
+type SemiFungibleTransferPayload struct {
+	TokenIDs     []*big.Int
+	Amounts      []*big.Int
+	Recipient    []byte
+	TransferData []byte
+}
+
+func (p *SemiFungibleTransferPayload) TransferType() TransferType {
+	return SemiFungibleTransfer
+}
+
+// GenericTransferPayload carries opaque metadata passed to the destination handler unchanged
+type GenericTransferPayload struct {
+	Metadata []byte
+}
+
+func (p *GenericTransferPayload) TransferType() TransferType {
+	return GenericTransfer
+}
+
+// FungiblePayload returns the message payload of a fungible transfer
+func (m *Message) FungiblePayload() (*FungibleTransferPayload, error) {
+	p, ok := m.Payload.(*FungibleTransferPayload)
+	if !ok {
+		return nil, fmt.Errorf("expected fungible transfer payload, got %T", m.Payload)
+	}
+	return p, nil
+}
+
+// NonFungiblePayload returns the message payload of a non-fungible transfer
+func (m *Message) NonFungiblePayload() (*NonFungibleTransferPayload, error) {
+	p, ok := m.Payload.(*NonFungibleTransferPayload)
+	if !ok {
+		return nil, fmt.Errorf("expected non-fungible transfer payload, got %T", m.Payload)
+	}
+	return p, nil
+}
+
+// SemiFungiblePayload returns the message payload of a semi-fungible transfer
+func (m *Message) SemiFungiblePayload() (*SemiFungibleTransferPayload, error) {
+	p, ok := m.Payload.(*SemiFungibleTransferPayload)
+	if !ok {
+		return nil, fmt.Errorf("expected semi-fungible transfer payload, got %T", m.Payload)
+	}
+	return p, nil
+}
+
+// GenericPayload returns the message payload of a generic transfer
+func (m *Message) GenericPayload() (*GenericTransferPayload, error) {
+	p, ok := m.Payload.(*GenericTransferPayload)
+	if !ok {
+		return nil, fmt.Errorf("expected generic transfer payload, got %T", m.Payload)
+	}
+	return p, nil
 }
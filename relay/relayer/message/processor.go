@@ -0,0 +1,31 @@
+package message
+
+import (
+	"fmt"
+	"time"
+)
+
+// MessageProcessor inspects a message before it is routed to its destination.
+// Processor can modify the message in place, return a DelayError to postpone
+// routing, return a HoldError to hold the message for an operator or return
+// any other error to reject the message.
+type MessageProcessor func(m *Message) error
+
+// DelayError postpones routing of the message for Delay, after which processors are run again
+type DelayError struct {
+	Delay  time.Duration
+	Reason string
+}
+
+func (e *DelayError) Error() string {
+	return fmt.Sprintf("message delayed for %s: %s", e.Delay, e.Reason)
+}
+
+// HoldError holds the message back until an operator releases or rejects it
+type HoldError struct {
+	Reason string
+}
+
+func (e *HoldError) Error() string {
+	return fmt.Sprintf("message held: %s", e.Reason)
+}
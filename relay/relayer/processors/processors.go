@@ -0,0 +1,171 @@
+package processors
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/mitchellh/mapstructure"
+	"github.com/mpetrun5/diplomski-projekt/relayer/message"
+)
+
+const (
+	ResourceAllowlistType = "resourceAllowlist"
+	RecipientDenylistType = "recipientDenylist"
+	AmountLimitType       = "amountLimit"
+)
+
+type RawResourceAllowlistConfig struct {
+	ResourceIDs []string `mapstructure:"resourceIds"`
+}
+
+type RawRecipientDenylistConfig struct {
+	Recipients []string `mapstructure:"recipients"`
+}
+
+type RawAmountLimitConfig struct {
+	Min string `mapstructure:"min"`
+	Max string `mapstructure:"max"`
+}
+
+// NewProcessors creates message processors, in the configured order, from raw processor configs
+func NewProcessors(processorConfigs []map[string]interface{}) ([]message.MessageProcessor, error) {
+	processors := make([]message.MessageProcessor, len(processorConfigs))
+	for i, processorConfig := range processorConfigs {
+		processor, err := newProcessor(processorConfig)
+		if err != nil {
+			return nil, fmt.Errorf("invalid processor %v config: %w", i, err)
+		}
+		processors[i] = processor
+	}
+	return processors, nil
+}
+
+func newProcessor(processorConfig map[string]interface{}) (message.MessageProcessor, error) {
+	switch processorConfig["type"] {
+	case ResourceAllowlistType:
+		var c RawResourceAllowlistConfig
+		if err := mapstructure.Decode(processorConfig, &c); err != nil {
+			return nil, err
+		}
+		return NewResourceAllowlist(c.ResourceIDs)
+	case RecipientDenylistType:
+		var c RawRecipientDenylistConfig
+		if err := mapstructure.Decode(processorConfig, &c); err != nil {
+			return nil, err
+		}
+		return NewRecipientDenylist(c.Recipients)
+	case AmountLimitType:
+		var c RawAmountLimitConfig
+		if err := mapstructure.Decode(processorConfig, &c); err != nil {
+			return nil, err
+		}
+		return NewAmountLimit(c.Min, c.Max)
+	default:
+		return nil, fmt.Errorf("unknown processor type %v", processorConfig["type"])
+	}
+}
+
+// NewResourceAllowlist rejects messages whose resource ID is not allowlisted
+func NewResourceAllowlist(resourceIDs []string) (message.MessageProcessor, error) {
+	allowed := make(map[[32]byte]struct{})
+	for _, id := range resourceIDs {
+		b, err := hexutil.Decode(id)
+		if err != nil || len(b) != 32 {
+			return nil, fmt.Errorf("invalid resource ID %s", id)
+		}
+		var resourceID [32]byte
+		copy(resourceID[:], b)
+		allowed[resourceID] = struct{}{}
+	}
+
+	return func(m *message.Message) error {
+		if _, ok := allowed[m.ResourceId]; !ok {
+			return fmt.Errorf("resource ID %s is not allowlisted", hexutil.Encode(m.ResourceId[:]))
+		}
+		return nil
+	}, nil
+}
+
+// NewRecipientDenylist rejects fungible, non-fungible and semi-fungible transfers to denylisted
+// recipients. Generic transfers have no recipient and are not checked.
+func NewRecipientDenylist(recipients []string) (message.MessageProcessor, error) {
+	denied := make(map[string]struct{})
+	for _, r := range recipients {
+		b, err := hexutil.Decode(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recipient %s", r)
+		}
+		denied[hexutil.Encode(b)] = struct{}{}
+	}
+
+	return func(m *message.Message) error {
+		recipient, ok := transferRecipient(m)
+		if !ok {
+			return nil
+		}
+		if _, ok := denied[hexutil.Encode(recipient)]; ok {
+			return fmt.Errorf("recipient %s is denylisted", hexutil.Encode(recipient))
+		}
+		return nil
+	}, nil
+}
+
+// NewAmountLimit rejects fungible transfers with amount outside of [min, max].
+// Empty min or max leaves that side of the range unbounded. The limit applies to all resources,
+// so non-fungible and semi-fungible transfers, whose amounts are token counts, are not checked.
+func NewAmountLimit(min, max string) (message.MessageProcessor, error) {
+	minAmount, err := parseAmount(min)
+	if err != nil {
+		return nil, err
+	}
+	maxAmount, err := parseAmount(max)
+	if err != nil {
+		return nil, err
+	}
+	if minAmount != nil && maxAmount != nil && minAmount.Cmp(maxAmount) == 1 {
+		return nil, fmt.Errorf("min amount %s greater than max amount %s", minAmount, maxAmount)
+	}
+
+	return func(m *message.Message) error {
+		if m.Type != message.FungibleTransfer {
+			return nil
+		}
+		payload, err := m.FungiblePayload()
+		if err != nil {
+			return err
+		}
+		if minAmount != nil && payload.Amount.Cmp(minAmount) == -1 {
+			return fmt.Errorf("amount %s below minimum %s", payload.Amount, minAmount)
+		}
+		if maxAmount != nil && payload.Amount.Cmp(maxAmount) == 1 {
+			return fmt.Errorf("amount %s above maximum %s", payload.Amount, maxAmount)
+		}
+		return nil
+	}, nil
+}
+
+// transferRecipient returns the recipient of the transfer, if the transfer type has one
+func transferRecipient(m *message.Message) ([]byte, bool) {
+	switch p := m.Payload.(type) {
+	case *message.FungibleTransferPayload:
+		return p.Recipient, true
+	case *message.NonFungibleTransferPayload:
+		return p.Recipient, true
+	case *message.SemiFungibleTransferPayload:
+		return p.Recipient, true
+	default:
+		return nil, false
+	}
+}
+
+func parseAmount(amount string) (*big.Int, error) {
+	if amount == "" {
+		return nil, nil
+	}
+	a, ok := new(big.Int).SetString(amount, 10)
+	if !ok || a.Sign() == -1 {
+		return nil, fmt.Errorf("invalid amount %s", amount)
+	}
+	return a, nil
+}
@@ -0,0 +1,198 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/mitchellh/mapstructure"
+	"github.com/mpetrun5/diplomski-projekt/relayer/message"
+	"github.com/mpetrun5/diplomski-projekt/store"
+)
+
+// Limit caps the cumulative amount of the resource relayed to the destination over a sliding window.
+// For non-fungible resources the amount is the number of tokens, one per ERC721 transfer and
+// the sum of batch amounts per ERC1155 transfer.
+type Limit struct {
+	ResourceID  [32]byte
+	Destination uint8
+	Window      time.Duration
+	Cap         *big.Int
+}
+
+type RawLimitConfig struct {
+	ResourceID  string `mapstructure:"resourceId"`
+	Destination uint8  `mapstructure:"destination"`
+	Window      int64  `mapstructure:"window"`
+	Cap         string `mapstructure:"cap"`
+}
+
+type RateLimitStore interface {
+	RecordTransfer(destination uint8, resourceID [32]byte, t *store.RelayedTransfer) error
+	HasTransfer(destination uint8, resourceID [32]byte, source uint8, depositNonce uint64) (bool, error)
+	Transfers(destination uint8, resourceID [32]byte) ([]*store.RelayedTransfer, error)
+	PruneTransfers(destination uint8, resourceID [32]byte, before time.Time) error
+	IsReleased(m *message.Message) (bool, error)
+	RemoveRelease(m *message.Message) error
+}
+
+// NewLimits parses raw rate limit configs
+func NewLimits(limitConfigs []map[string]interface{}) ([]Limit, error) {
+	limits := make([]Limit, len(limitConfigs))
+	for i, limitConfig := range limitConfigs {
+		var c RawLimitConfig
+		err := mapstructure.Decode(limitConfig, &c)
+		if err != nil {
+			return nil, err
+		}
+
+		resourceID, err := hexutil.Decode(c.ResourceID)
+		if err != nil || len(resourceID) != 32 {
+			return nil, fmt.Errorf("invalid rate limit %v resource ID %s", i, c.ResourceID)
+		}
+		copy(limits[i].ResourceID[:], resourceID)
+
+		if c.Window <= 0 {
+			return nil, fmt.Errorf("invalid rate limit %v window %v", i, c.Window)
+		}
+		limits[i].Window = time.Duration(c.Window) * time.Second
+
+		limitCap, ok := new(big.Int).SetString(c.Cap, 10)
+		if !ok || limitCap.Sign() == -1 {
+			return nil, fmt.Errorf("invalid rate limit %v cap %s", i, c.Cap)
+		}
+		limits[i].Cap = limitCap
+		limits[i].Destination = c.Destination
+	}
+	return limits, nil
+}
+
+// RateLimiter holds transfers that would exceed any of the configured limits
+type RateLimiter struct {
+	limits []Limit
+	store  RateLimitStore
+	lock   sync.Mutex
+}
+
+func NewRateLimiter(limits []Limit, store RateLimitStore) *RateLimiter {
+	return &RateLimiter{
+		limits: limits,
+		store:  store,
+	}
+}
+
+// Process is a message processor that returns HoldError if the transfer exceeds a limit,
+// otherwise the transfer amount is counted against limits of its resource and destination.
+// Messages released by an operator are counted but never held.
+func (l *RateLimiter) Process(m *message.Message) error {
+	amount, ok := transferAmount(m)
+	if !ok {
+		return nil
+	}
+	limits := l.matchingLimits(m)
+	if len(limits) == 0 {
+		return nil
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	counted, err := l.store.HasTransfer(m.Destination, m.ResourceId, m.Source, m.DepositNonce)
+	if err != nil {
+		return err
+	}
+	if counted {
+		return nil
+	}
+
+	released, err := l.store.IsReleased(m)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if !released {
+		transfers, err := l.store.Transfers(m.Destination, m.ResourceId)
+		if err != nil {
+			return err
+		}
+
+		for _, limit := range limits {
+			relayed := relayedSince(transfers, now.Add(-limit.Window))
+			if new(big.Int).Add(relayed, amount).Cmp(limit.Cap) == 1 {
+				return &message.HoldError{
+					Reason: fmt.Sprintf("transfer of %s exceeds cap %s per %s, %s already relayed", amount, limit.Cap, limit.Window, relayed),
+				}
+			}
+		}
+	}
+
+	err = l.store.RecordTransfer(m.Destination, m.ResourceId, &store.RelayedTransfer{
+		Source:       m.Source,
+		DepositNonce: m.DepositNonce,
+		Amount:       amount,
+		RelayedAt:    now,
+	})
+	if err != nil {
+		return err
+	}
+	if released {
+		err = l.store.RemoveRelease(m)
+		if err != nil {
+			return err
+		}
+	}
+
+	return l.store.PruneTransfers(m.Destination, m.ResourceId, now.Add(-maxWindow(limits)))
+}
+
+func (l *RateLimiter) matchingLimits(m *message.Message) []Limit {
+	limits := []Limit{}
+	for _, limit := range l.limits {
+		if limit.ResourceID == m.ResourceId && limit.Destination == m.Destination {
+			limits = append(limits, limit)
+		}
+	}
+	return limits
+}
+
+// transferAmount returns the amount of the transfer counted against limits. Generic transfers
+// don't transfer tokens and are not limited.
+func transferAmount(m *message.Message) (*big.Int, bool) {
+	switch p := m.Payload.(type) {
+	case *message.FungibleTransferPayload:
+		return p.Amount, true
+	case *message.NonFungibleTransferPayload:
+		return big.NewInt(1), true
+	case *message.SemiFungibleTransferPayload:
+		amount := big.NewInt(0)
+		for _, a := range p.Amounts {
+			amount.Add(amount, a)
+		}
+		return amount, true
+	default:
+		return nil, false
+	}
+}
+
+func relayedSince(transfers []*store.RelayedTransfer, since time.Time) *big.Int {
+	relayed := big.NewInt(0)
+	for _, t := range transfers {
+		if t.RelayedAt.After(since) {
+			relayed.Add(relayed, t.Amount)
+		}
+	}
+	return relayed
+}
+
+func maxWindow(limits []Limit) time.Duration {
+	var window time.Duration
+	for _, limit := range limits {
+		if limit.Window > window {
+			window = limit.Window
+		}
+	}
+	return window
+}
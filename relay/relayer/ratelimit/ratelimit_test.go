@@ -0,0 +1,253 @@
+package ratelimit
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/mpetrun5/diplomski-projekt/lvldb"
+	"github.com/mpetrun5/diplomski-projekt/relayer/message"
+	"github.com/mpetrun5/diplomski-projekt/store"
+)
+
+var testResourceID = [32]byte{1}
+
+func newTestStore(t *testing.T) *store.RateLimitStore {
+	db, err := lvldb.NewLvlDB(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		db.Close()
+	})
+	return store.NewRateLimitStore(db)
+}
+
+func newTestLimiter(t *testing.T, window time.Duration, limitCap int64) (*RateLimiter, *store.RateLimitStore) {
+	s := newTestStore(t)
+	return NewRateLimiter([]Limit{{
+		ResourceID:  testResourceID,
+		Destination: 2,
+		Window:      window,
+		Cap:         big.NewInt(limitCap),
+	}}, s), s
+}
+
+func fungibleMessage(nonce uint64, amount int64) *message.Message {
+	return &message.Message{
+		Source:       1,
+		Destination:  2,
+		DepositNonce: nonce,
+		ResourceId:   testResourceID,
+		Type:         message.FungibleTransfer,
+		Payload:      &message.FungibleTransferPayload{Amount: big.NewInt(amount)},
+	}
+}
+
+func isHeld(err error) bool {
+	var holdErr *message.HoldError
+	return errors.As(err, &holdErr)
+}
+
+func TestNewLimits(t *testing.T) {
+	resourceID := "0x0100000000000000000000000000000000000000000000000000000000000000"
+	tests := []struct {
+		name    string
+		config  map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name:   "valid limit",
+			config: map[string]interface{}{"resourceId": resourceID, "destination": 2, "window": 3600, "cap": "1000"},
+		},
+		{
+			name:    "short resource ID",
+			config:  map[string]interface{}{"resourceId": "0x01", "destination": 2, "window": 3600, "cap": "1000"},
+			wantErr: true,
+		},
+		{
+			name:    "missing window",
+			config:  map[string]interface{}{"resourceId": resourceID, "destination": 2, "cap": "1000"},
+			wantErr: true,
+		},
+		{
+			name:    "negative cap",
+			config:  map[string]interface{}{"resourceId": resourceID, "destination": 2, "window": 3600, "cap": "-1"},
+			wantErr: true,
+		},
+		{
+			name:    "cap not a number",
+			config:  map[string]interface{}{"resourceId": resourceID, "destination": 2, "window": 3600, "cap": "1e18"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limits, err := NewLimits([]map[string]interface{}{tt.config})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if limits[0].ResourceID != testResourceID || limits[0].Window != time.Hour || limits[0].Cap.Int64() != 1000 {
+				t.Fatalf("unexpected limit %+v", limits[0])
+			}
+		})
+	}
+}
+
+func TestRateLimiter_HoldsTransferExceedingCap(t *testing.T) {
+	limiter, _ := newTestLimiter(t, time.Hour, 100)
+
+	err := limiter.Process(fungibleMessage(1, 60))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = limiter.Process(fungibleMessage(2, 40))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = limiter.Process(fungibleMessage(3, 1))
+	if !isHeld(err) {
+		t.Fatalf("expected transfer to be held, got %v", err)
+	}
+}
+
+func TestRateLimiter_CountsTransferOnce(t *testing.T) {
+	limiter, _ := newTestLimiter(t, time.Hour, 100)
+
+	for i := 0; i < 3; i++ {
+		err := limiter.Process(fungibleMessage(1, 60))
+		if err != nil {
+			t.Fatalf("processing the same transfer again failed: %v", err)
+		}
+	}
+	err := limiter.Process(fungibleMessage(2, 40))
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRateLimiter_IgnoresTransfersOutsideWindow(t *testing.T) {
+	limiter, s := newTestLimiter(t, time.Hour, 100)
+	err := s.RecordTransfer(2, testResourceID, &store.RelayedTransfer{
+		Source:       1,
+		DepositNonce: 1,
+		Amount:       big.NewInt(100),
+		RelayedAt:    time.Now().Add(-2 * time.Hour),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = limiter.Process(fungibleMessage(2, 100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	transfers, err := s.Transfers(2, testResourceID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(transfers) != 1 || transfers[0].DepositNonce != 2 {
+		t.Fatalf("expected transfers outside the window to be pruned, got %v transfers", len(transfers))
+	}
+}
+
+func TestRateLimiter_ReleasedTransferBypassesCap(t *testing.T) {
+	limiter, s := newTestLimiter(t, time.Hour, 100)
+	m := fungibleMessage(1, 500)
+	err := s.ReleaseMessage(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = limiter.Process(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	released, err := s.IsReleased(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if released {
+		t.Fatal("expected release to be removed once the transfer was counted")
+	}
+	err = limiter.Process(fungibleMessage(2, 1))
+	if !isHeld(err) {
+		t.Fatalf("expected released transfer to count against the cap, got %v", err)
+	}
+}
+
+func TestRateLimiter_IgnoresUnlimitedTransfers(t *testing.T) {
+	limiter, _ := newTestLimiter(t, time.Hour, 0)
+
+	otherResource := fungibleMessage(1, 10)
+	otherResource.ResourceId = [32]byte{2}
+	otherDestination := fungibleMessage(2, 10)
+	otherDestination.Destination = 3
+	generic := &message.Message{
+		Source:       1,
+		Destination:  2,
+		DepositNonce: 3,
+		ResourceId:   testResourceID,
+		Type:         message.GenericTransfer,
+		Payload:      &message.GenericTransferPayload{Metadata: []byte{1}},
+	}
+
+	for _, m := range []*message.Message{otherResource, otherDestination, generic} {
+		err := limiter.Process(m)
+		if err != nil {
+			t.Fatalf("expected message %+v not to be limited, got %v", m, err)
+		}
+	}
+}
+
+func TestTransferAmount(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload message.Payload
+		amount  int64
+		limited bool
+	}{
+		{
+			name:    "fungible",
+			payload: &message.FungibleTransferPayload{Amount: big.NewInt(42)},
+			amount:  42,
+			limited: true,
+		},
+		{
+			name:    "non-fungible",
+			payload: &message.NonFungibleTransferPayload{TokenID: big.NewInt(7)},
+			amount:  1,
+			limited: true,
+		},
+		{
+			name:    "semi-fungible",
+			payload: &message.SemiFungibleTransferPayload{Amounts: []*big.Int{big.NewInt(3), big.NewInt(4)}},
+			amount:  7,
+			limited: true,
+		},
+		{
+			name:    "generic",
+			payload: &message.GenericTransferPayload{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amount, limited := transferAmount(&message.Message{Payload: tt.payload})
+			if limited != tt.limited {
+				t.Fatalf("expected limited %v, got %v", tt.limited, limited)
+			}
+			if limited && amount.Int64() != tt.amount {
+				t.Fatalf("expected amount %v, got %v", tt.amount, amount)
+			}
+		})
+	}
+}
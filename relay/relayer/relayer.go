@@ -4,12 +4,29 @@
 package relayer
 
 import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
 	"github.com/mpetrun5/diplomski-projekt/relayer/message"
 	"github.com/rs/zerolog/log"
 )
 
+var (
+	maxRetries         = 10
+	baseRetryInterval  = 5 * time.Second
+	maxRetryInterval   = 5 * time.Minute
+	retryBackoffFactor = 2
+)
+
+var errStopped = errors.New("relayer stopped")
+
 type Metrics interface {
 	TrackDepositMessage(m *message.Message)
+	TrackVoteSent(m *message.Message)
+	TrackVoteFailure(m *message.Message)
+	TrackQueueDepth(domainID uint8, depth int)
 }
 
 type RelayedChain interface {
@@ -18,52 +35,342 @@ type RelayedChain interface {
 	DomainID() uint8
 }
 
-func NewRelayer(chains []RelayedChain) *Relayer {
-	return &Relayer{relayedChains: chains}
+// MessageQueue holds messages that are waiting to be delivered to their destination
+type MessageQueue interface {
+	QueuedMessages(destination uint8) ([]*message.Message, error)
+	QueuedMessage(m *message.Message) (*message.Message, error)
+	Remove(m *message.Message) error
+}
+
+// DeadLetterQueue holds messages whose delivery failed after all retries
+type DeadLetterQueue interface {
+	StoreFailedMessage(m *message.Message, lastErr error, attempts int, firstFailedAt time.Time) error
+}
+
+// HoldQueue holds messages until an operator releases or rejects them
+type HoldQueue interface {
+	HoldMessage(m *message.Message, reason string) error
+}
+
+// NewRelayer creates a relayer that delivers messages to each destination with
+// the provided number of workers, holding at most queueSize messages per destination in memory.
+// Messages of a source are delivered in nonce order, workers deliver messages of different
+// sources concurrently. Processors are run in order on every message before it is routed.
+func NewRelayer(
+	chains []RelayedChain,
+	metrics Metrics,
+	queue MessageQueue,
+	deadLetterQueue DeadLetterQueue,
+	holdQueue HoldQueue,
+	processors []message.MessageProcessor,
+	workers int,
+	queueSize int,
+) *Relayer {
+	return &Relayer{
+		relayedChains:   chains,
+		metrics:         metrics,
+		processors:      processors,
+		queue:           queue,
+		deadLetterQueue: deadLetterQueue,
+		holdQueue:       holdQueue,
+		workerCount:     workers,
+		queueSize:       queueSize,
+		workers:         make(map[uint8]*destinationWorker),
+		deliveries:      make(map[string]*delivery),
+	}
+}
+
+// delivery tracks failed delivery attempts of a message waiting to be retried
+type delivery struct {
+	attempts      int
+	firstFailedAt time.Time
+	retryInterval time.Duration
 }
 
 type Relayer struct {
-	metrics       Metrics
-	relayedChains []RelayedChain
-	registry      map[uint8]RelayedChain
+	metrics         Metrics
+	relayedChains   []RelayedChain
+	registry        map[uint8]RelayedChain
+	queue           MessageQueue
+	deadLetterQueue DeadLetterQueue
+	holdQueue       HoldQueue
+	processors      []message.MessageProcessor
+	workerCount     int
+	queueSize       int
+	workers         map[uint8]*destinationWorker
+	deliveries      map[string]*delivery
+	deliveriesLock  sync.Mutex
+	wg              sync.WaitGroup
+	stop            <-chan struct{}
 }
 
+// Start starts polling relayed chains and routing messages. Once stop is closed it
+// returns after all chains stopped polling and in-flight messages were handled.
 func (r *Relayer) Start(stop <-chan struct{}, sysErr chan error) {
 	log.Debug().Msgf("Starting relayer")
+	r.stop = stop
+	defer r.wg.Wait()
 
-	messagesChannel := make(chan *message.Message)
+	// every chain gets its own channel so that a full destination only holds back
+	// listeners of chains that send messages to it
+	messageChannels := make([]chan *message.Message, 0, len(r.relayedChains))
 	for _, c := range r.relayedChains {
 		log.Debug().Msgf("Starting chain %v", c.DomainID())
 		r.addRelayedChain(c)
-		go c.PollEvents(stop, sysErr, messagesChannel)
+		msgs := make(chan *message.Message)
+		messageChannels = append(messageChannels, msgs)
+		r.wg.Add(1)
+		go func(c RelayedChain) {
+			defer r.wg.Done()
+			c.PollEvents(stop, sysErr, msgs)
+		}(c)
 	}
+	r.startWorkers()
 
-	for {
+	err := r.routeQueuedMessages()
+	if err != nil {
 		select {
-		case m := <-messagesChannel:
-			go r.route(m)
-			continue
+		case sysErr <- err:
 		case <-stop:
+		}
+		return
+	}
+
+	for _, msgs := range messageChannels {
+		r.wg.Add(1)
+		go func(msgs <-chan *message.Message) {
+			defer r.wg.Done()
+			r.forward(msgs)
+		}(msgs)
+	}
+
+	<-stop
+	log.Info().Msg("Stopping relayer, waiting for in-flight messages")
+}
+
+// forward submits messages received from a chain until stop is closed
+func (r *Relayer) forward(msgs <-chan *message.Message) {
+	for {
+		select {
+		case m := <-msgs:
+			r.submit(m)
+		case <-r.stop:
 			return
 		}
 	}
 }
 
-func (r *Relayer) route(m *message.Message) {
-	r.metrics.TrackDepositMessage(m)
+// routeQueuedMessages resumes delivery of messages that were queued before the relayer stopped
+func (r *Relayer) routeQueuedMessages() error {
+	for domainID := range r.registry {
+		msgs, err := r.queue.QueuedMessages(domainID)
+		if err != nil {
+			return fmt.Errorf("failed loading queued messages for domain %v: %w", domainID, err)
+		}
+		if len(msgs) > 0 {
+			log.Info().Msgf("Resuming delivery of %v queued messages to domain %v", len(msgs), domainID)
+		}
+		for _, m := range msgs {
+			r.submit(m)
+		}
+	}
+	return nil
+}
+
+// startWorkers starts delivery workers for every registered destination domain
+func (r *Relayer) startWorkers() {
+	for domainID := range r.registry {
+		w := newDestinationWorker(domainID, r.queueSize, r.route, r.metrics, r.stop)
+		r.workers[domainID] = w
+		for i := 0; i < r.workerCount; i++ {
+			r.wg.Add(1)
+			go func() {
+				defer r.wg.Done()
+				w.run()
+			}()
+		}
+	}
+}
 
-	destChain, ok := r.registry[m.Destination]
+// submit hands the message over to the worker of its destination. It blocks while the destination
+// queue is full, which holds back the listener that sent the message until the destination catches up.
+func (r *Relayer) submit(m *message.Message) {
+	w, ok := r.workers[m.Destination]
 	if !ok {
 		log.Error().Msgf("no resolver for destID %v to send message registered", m.Destination)
 		return
 	}
+	w.submit(m)
+}
+
+// route delivers the message to its destination. If writing fails it returns the delay after
+// which the message should be routed again, otherwise it returns zero.
+func (r *Relayer) route(m *message.Message) time.Duration {
+	destChain, ok := r.registry[m.Destination]
+	if !ok {
+		log.Error().Msgf("no resolver for destID %v to send message registered", m.Destination)
+		return 0
+	}
+
+	// the listener retracts messages of reorged blocks, which can happen while retrying
+	queued, ok := r.queuedMessage(m)
+	if !ok {
+		r.removeDelivery(m)
+		return 0
+	}
+	m = queued
+
+	d := r.delivery(m)
+	if d == nil {
+		r.metrics.TrackDepositMessage(m)
+
+		err := r.process(m)
+		if errors.Is(err, errStopped) {
+			return 0
+		}
+		var holdErr *message.HoldError
+		if errors.As(err, &holdErr) {
+			log.Warn().Msgf("Message %+v held: %s", m, holdErr.Reason)
+			r.moveToHoldQueue(m, holdErr.Reason)
+			return 0
+		}
+		if err != nil {
+			log.Warn().Err(err).Msgf("Message %+v rejected, moving it to dead-letter queue", m)
+			r.moveToDeadLetterQueue(m, err, 0, time.Now())
+			return 0
+		}
+		d = &delivery{retryInterval: baseRetryInterval}
+	}
 
 	log.Debug().Msgf("Sending message %+v to destination %v", m, m.Destination)
 
-	if err := destChain.Write(m); err != nil {
-		log.Error().Err(err).Msgf("writing message %+v", m)
+	err := destChain.Write(m)
+	if err == nil {
+		r.metrics.TrackVoteSent(m)
+		r.removeDelivery(m)
+		err = r.queue.Remove(m)
+		if err != nil {
+			log.Error().Err(err).Msgf("Failed removing delivered message %+v from queue", m)
+		}
+		return 0
+	}
+
+	d.attempts++
+	log.Error().Err(err).Int("attempt", d.attempts).Msgf("writing message %+v", m)
+	r.metrics.TrackVoteFailure(m)
+	if d.firstFailedAt.IsZero() {
+		d.firstFailedAt = time.Now()
+	}
+	if d.attempts >= maxRetries {
+		log.Error().Msgf("Retries exhausted for message %+v, moving it to dead-letter queue", m)
+		r.removeDelivery(m)
+		r.moveToDeadLetterQueue(m, err, d.attempts, d.firstFailedAt)
+		return 0
+	}
+
+	retryInterval := d.retryInterval
+	d.retryInterval = nextRetryInterval(retryInterval)
+	r.storeDelivery(m, d)
+	return retryInterval
+}
+
+// delivery returns failed delivery attempts of the message, or nil if it wasn't written yet
+func (r *Relayer) delivery(m *message.Message) *delivery {
+	r.deliveriesLock.Lock()
+	defer r.deliveriesLock.Unlock()
+	return r.deliveries[messageKey(m)]
+}
+
+func (r *Relayer) storeDelivery(m *message.Message, d *delivery) {
+	r.deliveriesLock.Lock()
+	defer r.deliveriesLock.Unlock()
+	r.deliveries[messageKey(m)] = d
+}
+
+func (r *Relayer) removeDelivery(m *message.Message) {
+	r.deliveriesLock.Lock()
+	defer r.deliveriesLock.Unlock()
+	delete(r.deliveries, messageKey(m))
+}
+
+// queuedMessage returns the queued version of the message, which differs from the routed
+// message if the listener replaced it after a reorg. Messages that are not queued anymore
+// were retracted or already delivered and are not routed.
+func (r *Relayer) queuedMessage(m *message.Message) (*message.Message, bool) {
+	queued, err := r.queue.QueuedMessage(m)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed reading queued message %+v, routing it as received", m)
+		return m, true
+	}
+	if queued == nil {
+		log.Warn().Msgf("Message %+v is not queued anymore, skipping delivery", m)
+		return nil, false
+	}
+	return queued, true
+}
+
+// process runs message processors on the message. Delayed messages are processed
+// again once the delay passes, so any returned error other than errStopped or HoldError
+// means the message was rejected.
+func (r *Relayer) process(m *message.Message) error {
+	for i := 0; i < len(r.processors); i++ {
+		err := r.processors[i](m)
+		if err == nil {
+			continue
+		}
+
+		var delayErr *message.DelayError
+		if !errors.As(err, &delayErr) {
+			return err
+		}
+
+		log.Info().Msgf("Message %+v delayed for %s: %s", m, delayErr.Delay, delayErr.Reason)
+		select {
+		case <-time.After(delayErr.Delay):
+			i = -1
+		case <-r.stop:
+			return errStopped
+		}
+	}
+	return nil
+}
+
+func (r *Relayer) moveToDeadLetterQueue(m *message.Message, lastErr error, attempts int, firstFailedAt time.Time) {
+	err := r.deadLetterQueue.StoreFailedMessage(m, lastErr, attempts, firstFailedAt)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed storing message %+v to dead-letter queue, message stays queued until the relayer restarts", m)
+		return
+	}
+
+	err = r.queue.Remove(m)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed removing dead-lettered message %+v from queue", m)
+	}
+}
+
+func (r *Relayer) moveToHoldQueue(m *message.Message, reason string) {
+	err := r.holdQueue.HoldMessage(m, reason)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed holding message %+v, message stays queued until the relayer restarts", m)
 		return
 	}
+
+	err = r.queue.Remove(m)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed removing held message %+v from queue", m)
+	}
+}
+
+func nextRetryInterval(interval time.Duration) time.Duration {
+	interval = interval * time.Duration(retryBackoffFactor)
+	if interval > maxRetryInterval {
+		return maxRetryInterval
+	}
+	return interval
+}
+
+func messageKey(m *message.Message) string {
+	return fmt.Sprintf("%d:%d:%d", m.Source, m.Destination, m.DepositNonce)
 }
 
 func (r *Relayer) addRelayedChain(c RelayedChain) {
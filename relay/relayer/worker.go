@@ -0,0 +1,202 @@
+package relayer
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+
+	"github.com/mpetrun5/diplomski-projekt/relayer/message"
+	"github.com/rs/zerolog/log"
+)
+
+// destinationWorker delivers messages to a single destination domain. Submitted messages are
+// held in a bounded queue per source domain ordered by deposit nonce. Messages of a source are
+// delivered one at a time in nonce order, while a configurable number of goroutines delivers
+// messages of different sources concurrently. A message that has to be retried stays at the head
+// of its source queue and holds back later nonces of the source until it is handled.
+type destinationWorker struct {
+	domainID  uint8
+	queueSize int
+	// route delivers the message and returns the delay after which delivery should be retried,
+	// or zero if the message was handled
+	route   func(m *message.Message) time.Duration
+	metrics Metrics
+
+	lock sync.Mutex
+	// cond is broadcast whenever the queue or source state changes and once the worker stops
+	cond    *sync.Cond
+	sources map[uint8]*messageHeap
+	// busy marks sources whose message is being delivered or is waiting to be retried
+	busy    map[uint8]bool
+	pending map[string]struct{}
+	// size counts queued messages, including messages being delivered or waiting to be retried
+	size    int
+	stopped bool
+}
+
+func newDestinationWorker(
+	domainID uint8,
+	queueSize int,
+	route func(m *message.Message) time.Duration,
+	metrics Metrics,
+	stop <-chan struct{},
+) *destinationWorker {
+	w := &destinationWorker{
+		domainID:  domainID,
+		queueSize: queueSize,
+		route:     route,
+		metrics:   metrics,
+		sources:   make(map[uint8]*messageHeap),
+		busy:      make(map[uint8]bool),
+		pending:   make(map[string]struct{}),
+	}
+	w.cond = sync.NewCond(&w.lock)
+
+	go func() {
+		<-stop
+		w.lock.Lock()
+		w.stopped = true
+		w.cond.Broadcast()
+		w.lock.Unlock()
+	}()
+	return w
+}
+
+// submit queues the message for delivery. It blocks while the queue is full or until
+// the worker stops. Messages that are already queued or being delivered are ignored.
+func (w *destinationWorker) submit(m *message.Message) {
+	key := messageKey(m)
+	w.lock.Lock()
+	for {
+		if _, ok := w.pending[key]; ok {
+			w.lock.Unlock()
+			log.Debug().Msgf("Message %+v is already queued for delivery", m)
+			return
+		}
+		if w.stopped {
+			w.lock.Unlock()
+			return
+		}
+		if w.size < w.queueSize {
+			break
+		}
+		w.cond.Wait()
+	}
+
+	w.pending[key] = struct{}{}
+	queue, ok := w.sources[m.Source]
+	if !ok {
+		queue = &messageHeap{}
+		w.sources[m.Source] = queue
+	}
+	heap.Push(queue, m)
+	w.size++
+	depth := w.size
+	w.cond.Broadcast()
+	w.lock.Unlock()
+
+	w.metrics.TrackQueueDepth(w.domainID, depth)
+}
+
+// next waits for the lowest nonce message of a source that is not busy and marks the
+// source busy. It returns nil once the worker stops.
+func (w *destinationWorker) next() *message.Message {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	for !w.stopped {
+		source, ok := w.readySource()
+		if ok {
+			w.busy[source] = true
+			return heap.Pop(w.sources[source]).(*message.Message)
+		}
+		w.cond.Wait()
+	}
+	return nil
+}
+
+// readySource returns the lowest source domain that has queued messages and is not busy
+func (w *destinationWorker) readySource() (uint8, bool) {
+	var source uint8
+	found := false
+	for s, queue := range w.sources {
+		if w.busy[s] || queue.Len() == 0 {
+			continue
+		}
+		if !found || s < source {
+			source = s
+			found = true
+		}
+	}
+	return source, found
+}
+
+// retry puts the message back at the head of its source queue. The source stays busy until
+// the delay passes, so later nonces of the source are not delivered before the message.
+func (w *destinationWorker) retry(m *message.Message, delay time.Duration) {
+	w.lock.Lock()
+	heap.Push(w.sources[m.Source], m)
+	w.lock.Unlock()
+
+	time.AfterFunc(delay, func() {
+		w.lock.Lock()
+		delete(w.busy, m.Source)
+		w.cond.Broadcast()
+		w.lock.Unlock()
+	})
+}
+
+// done removes the handled message from the queue and lets the next message of its source be delivered
+func (w *destinationWorker) done(m *message.Message) {
+	w.lock.Lock()
+	delete(w.pending, messageKey(m))
+	delete(w.busy, m.Source)
+	w.size--
+	depth := w.size
+	w.cond.Broadcast()
+	w.lock.Unlock()
+
+	w.metrics.TrackQueueDepth(w.domainID, depth)
+}
+
+// run delivers queued messages until the worker stops. Messages that have to be retried
+// release the goroutine while they wait so they don't hold up messages of other sources.
+func (w *destinationWorker) run() {
+	for {
+		m := w.next()
+		if m == nil {
+			return
+		}
+
+		delay := w.route(m)
+		if delay > 0 {
+			w.retry(m, delay)
+			continue
+		}
+		w.done(m)
+	}
+}
+
+// messageHeap orders messages of a source by deposit nonce
+type messageHeap []*message.Message
+
+func (h messageHeap) Len() int { return len(h) }
+
+func (h messageHeap) Less(i, j int) bool {
+	return h[i].DepositNonce < h[j].DepositNonce
+}
+
+func (h messageHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *messageHeap) Push(x interface{}) {
+	*h = append(*h, x.(*message.Message))
+}
+
+func (h *messageHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	m := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return m
+}
@@ -0,0 +1,176 @@
+package relayer
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mpetrun5/diplomski-projekt/relayer/message"
+)
+
+type noopMetrics struct{}
+
+func (noopMetrics) TrackDepositMessage(m *message.Message)    {}
+func (noopMetrics) TrackVoteSent(m *message.Message)          {}
+func (noopMetrics) TrackVoteFailure(m *message.Message)       {}
+func (noopMetrics) TrackQueueDepth(domainID uint8, depth int) {}
+
+func newTestMessage(source uint8, nonce uint64) *message.Message {
+	return &message.Message{Source: source, Destination: 2, DepositNonce: nonce}
+}
+
+// deliveryRecorder passes routed messages to the test in the order they were routed
+type deliveryRecorder struct {
+	routed chan *message.Message
+}
+
+func newDeliveryRecorder() *deliveryRecorder {
+	return &deliveryRecorder{routed: make(chan *message.Message, 100)}
+}
+
+func (r *deliveryRecorder) record(m *message.Message) {
+	r.routed <- m
+}
+
+func (r *deliveryRecorder) next(t *testing.T) *message.Message {
+	select {
+	case m := <-r.routed:
+		return m
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for message to be routed")
+		return nil
+	}
+}
+
+func startWorker(w *destinationWorker, workers int) {
+	for i := 0; i < workers; i++ {
+		go w.run()
+	}
+}
+
+func TestDestinationWorker_DeliversOutOfOrderArrivalsInNonceOrder(t *testing.T) {
+	stop := make(chan struct{})
+	defer close(stop)
+	recorder := newDeliveryRecorder()
+	release := make(chan struct{})
+	w := newDestinationWorker(2, 3, func(m *message.Message) time.Duration {
+		recorder.record(m)
+		<-release
+		return 0
+	}, noopMetrics{}, stop)
+
+	for _, nonce := range []uint64{3, 1, 2} {
+		w.submit(newTestMessage(1, nonce))
+	}
+	submitted := make(chan struct{})
+	go func() {
+		w.submit(newTestMessage(1, 4))
+		close(submitted)
+	}()
+	select {
+	case <-submitted:
+		t.Fatal("submit didn't block on a full queue")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	startWorker(w, 1)
+	for _, expected := range []uint64{1, 2, 3, 4} {
+		m := recorder.next(t)
+		if m.DepositNonce != expected {
+			t.Fatalf("expected nonce %v to be delivered, got %v", expected, m.DepositNonce)
+		}
+		release <- struct{}{}
+		if expected == 1 {
+			select {
+			case <-submitted:
+			case <-time.After(time.Second):
+				t.Fatal("submit didn't continue once the queue had room")
+			}
+		}
+	}
+}
+
+func TestDestinationWorker_RetryHoldsBackLaterNoncesOfSource(t *testing.T) {
+	stop := make(chan struct{})
+	defer close(stop)
+	recorder := newDeliveryRecorder()
+	failed := false
+	var failedLock sync.Mutex
+	w := newDestinationWorker(2, 10, func(m *message.Message) time.Duration {
+		recorder.record(m)
+		failedLock.Lock()
+		defer failedLock.Unlock()
+		if m.Source == 1 && m.DepositNonce == 1 && !failed {
+			failed = true
+			return 200 * time.Millisecond
+		}
+		return 0
+	}, noopMetrics{}, stop)
+
+	w.submit(newTestMessage(1, 2))
+	w.submit(newTestMessage(1, 1))
+	w.submit(newTestMessage(1, 3))
+	w.submit(newTestMessage(3, 1))
+	startWorker(w, 2)
+
+	var source1 []uint64
+	otherSourceDelivered := false
+	for i := 0; i < 5; i++ {
+		m := recorder.next(t)
+		if m.Source == 3 {
+			if len(source1) > 1 {
+				t.Fatal("message of another source was held back by the retried message")
+			}
+			otherSourceDelivered = true
+			continue
+		}
+		source1 = append(source1, m.DepositNonce)
+	}
+
+	if !otherSourceDelivered {
+		t.Fatal("message of another source wasn't delivered")
+	}
+	expected := []uint64{1, 1, 2, 3}
+	for i := range expected {
+		if source1[i] != expected[i] {
+			t.Fatalf("expected source deliveries %v, got %v", expected, source1)
+		}
+	}
+}
+
+func TestDestinationWorker_IgnoresQueuedMessages(t *testing.T) {
+	stop := make(chan struct{})
+	defer close(stop)
+	w := newDestinationWorker(2, 2, func(m *message.Message) time.Duration { return 0 }, noopMetrics{}, stop)
+
+	w.submit(newTestMessage(1, 1))
+	w.submit(newTestMessage(1, 1))
+
+	if w.size != 1 {
+		t.Fatalf("expected 1 queued message, got %v", w.size)
+	}
+}
+
+func TestDestinationWorker_SubmitReturnsOnStop(t *testing.T) {
+	stop := make(chan struct{})
+	w := newDestinationWorker(2, 1, func(m *message.Message) time.Duration { return 0 }, noopMetrics{}, stop)
+	w.submit(newTestMessage(1, 1))
+
+	submitted := make(chan struct{})
+	go func() {
+		w.submit(newTestMessage(1, 2))
+		close(submitted)
+	}()
+	close(stop)
+
+	select {
+	case <-submitted:
+	case <-time.After(time.Second):
+		t.Fatal("submit didn't return once the worker stopped")
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if _, ok := w.pending[messageKey(newTestMessage(1, 2))]; ok {
+		t.Fatal("message submitted after stop is marked pending")
+	}
+}
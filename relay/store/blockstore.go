@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mpetrun5/diplomski-projekt/relayer/message"
 	"github.com/syndtr/goleveldb/leveldb"
 )
 
@@ -33,6 +35,68 @@ func (bs *BlockStore) StoreBlock(block *big.Int, domainID uint8) error {
 	return nil
 }
 
+// BlockRecord is a processed block range with the hash of its last block and the messages
+// found in the range. Records are used to detect reorgs and retract messages of reorged blocks.
+type BlockRecord struct {
+	StartBlock *big.Int
+	EndBlock   *big.Int
+	Hash       common.Hash
+	Messages   []*message.Message
+}
+
+// StoreBlockRecord stores the processed block range record of the domain
+func (bs *BlockStore) StoreBlockRecord(domainID uint8, r *BlockRecord) error {
+	value, err := encode(r)
+	if err != nil {
+		return err
+	}
+	return bs.db.SetByKey(blockRecordKey(domainID, r.EndBlock), value)
+}
+
+// BlockRecords returns block range records of the domain ordered by block number
+func (bs *BlockStore) BlockRecords(domainID uint8) ([]*BlockRecord, error) {
+	values, err := bs.db.GetByPrefix([]byte(fmt.Sprintf("chain:%d:range:", domainID)))
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*BlockRecord, len(values))
+	for i, v := range values {
+		records[i] = &BlockRecord{}
+		err = decode(v, records[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return records, nil
+}
+
+// RemoveBlockRecord deletes the record of the block range ending with endBlock
+func (bs *BlockStore) RemoveBlockRecord(domainID uint8, endBlock *big.Int) error {
+	return bs.db.DeleteByKey(blockRecordKey(domainID, endBlock))
+}
+
+// PruneBlockRecords deletes all but the latest keep block range records of the domain
+func (bs *BlockStore) PruneBlockRecords(domainID uint8, keep int) error {
+	records, err := bs.BlockRecords(domainID)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < len(records)-keep; i++ {
+		err = bs.RemoveBlockRecord(domainID, records[i].EndBlock)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// blockRecordKey is zero padded so that records of a domain are ordered by block number
+func blockRecordKey(domainID uint8, endBlock *big.Int) []byte {
+	return []byte(fmt.Sprintf("chain:%d:range:%020d", domainID, endBlock.Uint64()))
+}
+
 // GetLastStoredBlock queries the blockstore and returns latest known block
 func (bs *BlockStore) GetLastStoredBlock(domainID uint8) (*big.Int, error) {
 	key := bytes.Buffer{}
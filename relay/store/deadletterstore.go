@@ -0,0 +1,110 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mpetrun5/diplomski-projekt/relayer/message"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// FailedMessage is a message whose delivery failed after all retries were exhausted
+type FailedMessage struct {
+	Message       *message.Message
+	LastError     string
+	Attempts      int
+	FirstFailedAt time.Time
+	LastFailedAt  time.Time
+}
+
+type DeadLetterStore struct {
+	db KeyValueReaderWriter
+}
+
+func NewDeadLetterStore(db KeyValueReaderWriter) *DeadLetterStore {
+	return &DeadLetterStore{
+		db: db,
+	}
+}
+
+// StoreFailedMessage persists the failed message. If the message already failed before,
+// attempts are added to the existing record and the first failure time is kept.
+func (ds *DeadLetterStore) StoreFailedMessage(m *message.Message, lastErr error, attempts int, firstFailedAt time.Time) error {
+	fm := &FailedMessage{
+		Message:       m,
+		LastError:     lastErr.Error(),
+		Attempts:      attempts,
+		FirstFailedAt: firstFailedAt,
+		LastFailedAt:  time.Now(),
+	}
+
+	existing, err := ds.GetFailedMessage(m.Destination, m.Source, m.DepositNonce)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	if existing != nil {
+		fm.Attempts += existing.Attempts
+		fm.FirstFailedAt = existing.FirstFailedAt
+	}
+
+	value, err := encode(fm)
+	if err != nil {
+		return err
+	}
+	return ds.db.SetByKey(deadLetterKey(m.Destination, m.Source, m.DepositNonce), value)
+}
+
+// GetFailedMessage returns the failed message identified by destination, source and deposit nonce
+func (ds *DeadLetterStore) GetFailedMessage(destination, source uint8, depositNonce uint64) (*FailedMessage, error) {
+	value, err := ds.db.GetByKey(deadLetterKey(destination, source, depositNonce))
+	if err != nil {
+		if errors.Is(err, leveldb.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	fm := &FailedMessage{}
+	err = decode(value, fm)
+	if err != nil {
+		return nil, err
+	}
+	return fm, nil
+}
+
+// FailedMessages returns failed messages for the destination domain ordered by source and deposit nonce
+func (ds *DeadLetterStore) FailedMessages(destination uint8) ([]*FailedMessage, error) {
+	return ds.failedMessagesByPrefix(fmt.Sprintf("deadletter:%03d:", destination))
+}
+
+// AllFailedMessages returns failed messages for all destination domains
+func (ds *DeadLetterStore) AllFailedMessages() ([]*FailedMessage, error) {
+	return ds.failedMessagesByPrefix("deadletter:")
+}
+
+// RemoveFailedMessage deletes the failed message from the store
+func (ds *DeadLetterStore) RemoveFailedMessage(m *message.Message) error {
+	return ds.db.DeleteByKey(deadLetterKey(m.Destination, m.Source, m.DepositNonce))
+}
+
+func (ds *DeadLetterStore) failedMessagesByPrefix(prefix string) ([]*FailedMessage, error) {
+	values, err := ds.db.GetByPrefix([]byte(prefix))
+	if err != nil {
+		return nil, err
+	}
+
+	msgs := make([]*FailedMessage, len(values))
+	for i, v := range values {
+		msgs[i] = &FailedMessage{}
+		err = decode(v, msgs[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return msgs, nil
+}
+
+func deadLetterKey(destination, source uint8, depositNonce uint64) []byte {
+	return []byte(fmt.Sprintf("deadletter:%03d:%03d:%020d", destination, source, depositNonce))
+}
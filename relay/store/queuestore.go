@@ -0,0 +1,88 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/mpetrun5/diplomski-projekt/relayer/message"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+type MessageQueueStore struct {
+	db KeyValueReaderWriter
+}
+
+func NewMessageQueueStore(db KeyValueReaderWriter) *MessageQueueStore {
+	return &MessageQueueStore{
+		db: db,
+	}
+}
+
+// Enqueue persists the message into the queue of its destination domain
+func (qs *MessageQueueStore) Enqueue(m *message.Message) error {
+	value, err := encodeMessage(m)
+	if err != nil {
+		return err
+	}
+
+	return qs.db.SetByKey(queueKey(m), value)
+}
+
+// Remove deletes the message from the queue of its destination domain
+func (qs *MessageQueueStore) Remove(m *message.Message) error {
+	return qs.db.DeleteByKey(queueKey(m))
+}
+
+// QueuedMessage returns the currently queued version of the message, or nil if the message
+// is not queued anymore
+func (qs *MessageQueueStore) QueuedMessage(m *message.Message) (*message.Message, error) {
+	value, err := qs.db.GetByKey(queueKey(m))
+	if err != nil {
+		if errors.Is(err, leveldb.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return decodeMessage(value)
+}
+
+// QueuedMessages returns all messages queued for the destination domain ordered
+// by source domain and deposit nonce
+func (qs *MessageQueueStore) QueuedMessages(destination uint8) ([]*message.Message, error) {
+	values, err := qs.db.GetByPrefix([]byte(fmt.Sprintf("queue:%03d:", destination)))
+	if err != nil {
+		return nil, err
+	}
+
+	msgs := make([]*message.Message, len(values))
+	for i, v := range values {
+		msgs[i], err = decodeMessage(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return msgs, nil
+}
+
+// queueKey is zero padded so that keys of a destination are ordered by source and deposit nonce
+func queueKey(m *message.Message) []byte {
+	return []byte(fmt.Sprintf("queue:%03d:%03d:%020d", m.Destination, m.Source, m.DepositNonce))
+}
+
+func encodeMessage(m *message.Message) ([]byte, error) {
+	return message.Encode(m)
+}
+
+func decodeMessage(value []byte) (*message.Message, error) {
+	return message.Decode(value)
+}
+
+// encode serializes store records as JSON, messages within records use their versioned encoding
+func encode(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func decode(value []byte, v interface{}) error {
+	return json.Unmarshal(value, v)
+}
@@ -0,0 +1,206 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/mpetrun5/diplomski-projekt/relayer/message"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// RelayedTransfer is a transfer amount counted against rate limits of its resource and destination
+type RelayedTransfer struct {
+	Source       uint8
+	DepositNonce uint64
+	Amount       *big.Int
+	RelayedAt    time.Time
+}
+
+// HeldMessage is a message held back because it exceeded a rate limit
+type HeldMessage struct {
+	Message *message.Message
+	Reason  string
+	HeldAt  time.Time
+}
+
+type RateLimitStore struct {
+	db KeyValueReaderWriter
+}
+
+func NewRateLimitStore(db KeyValueReaderWriter) *RateLimitStore {
+	return &RateLimitStore{
+		db: db,
+	}
+}
+
+// RecordTransfer persists the transfer amount relayed to the destination for the resource
+func (rs *RateLimitStore) RecordTransfer(destination uint8, resourceID [32]byte, t *RelayedTransfer) error {
+	value, err := encode(t)
+	if err != nil {
+		return err
+	}
+	return rs.db.SetByKey(transferKey(destination, resourceID, t.Source, t.DepositNonce), value)
+}
+
+// HasTransfer checks if the transfer was already counted against rate limits
+func (rs *RateLimitStore) HasTransfer(destination uint8, resourceID [32]byte, source uint8, depositNonce uint64) (bool, error) {
+	_, err := rs.db.GetByKey(transferKey(destination, resourceID, source, depositNonce))
+	if err != nil {
+		if errors.Is(err, leveldb.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// Transfers returns all recorded transfers of the resource to the destination
+func (rs *RateLimitStore) Transfers(destination uint8, resourceID [32]byte) ([]*RelayedTransfer, error) {
+	values, err := rs.db.GetByPrefix([]byte(fmt.Sprintf("ratelimit:%03d:%s:", destination, hexutil.Encode(resourceID[:]))))
+	if err != nil {
+		return nil, err
+	}
+
+	transfers := make([]*RelayedTransfer, len(values))
+	for i, v := range values {
+		transfers[i] = &RelayedTransfer{}
+		err = decode(v, transfers[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return transfers, nil
+}
+
+// PruneTransfers deletes transfers of the resource to the destination relayed before the provided time
+func (rs *RateLimitStore) PruneTransfers(destination uint8, resourceID [32]byte, before time.Time) error {
+	transfers, err := rs.Transfers(destination, resourceID)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range transfers {
+		if !t.RelayedAt.Before(before) {
+			continue
+		}
+		err = rs.db.DeleteByKey(transferKey(destination, resourceID, t.Source, t.DepositNonce))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HoldMessage persists the message as held until it is released or rejected
+func (rs *RateLimitStore) HoldMessage(m *message.Message, reason string) error {
+	value, err := encode(&HeldMessage{
+		Message: m,
+		Reason:  reason,
+		HeldAt:  time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	return rs.db.SetByKey(heldKey(m.Destination, m.Source, m.DepositNonce), value)
+}
+
+// GetHeldMessage returns the held message identified by destination, source and deposit nonce
+func (rs *RateLimitStore) GetHeldMessage(destination, source uint8, depositNonce uint64) (*HeldMessage, error) {
+	value, err := rs.db.GetByKey(heldKey(destination, source, depositNonce))
+	if err != nil {
+		if errors.Is(err, leveldb.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	hm := &HeldMessage{}
+	err = decode(value, hm)
+	if err != nil {
+		return nil, err
+	}
+	return hm, nil
+}
+
+// HeldMessages returns held messages for the destination domain ordered by source and deposit nonce
+func (rs *RateLimitStore) HeldMessages(destination uint8) ([]*HeldMessage, error) {
+	return rs.heldMessagesByPrefix(fmt.Sprintf("held:%03d:", destination))
+}
+
+// AllHeldMessages returns held messages for all destination domains
+func (rs *RateLimitStore) AllHeldMessages() ([]*HeldMessage, error) {
+	return rs.heldMessagesByPrefix("held:")
+}
+
+// RemoveHeldMessage deletes the held message from the store
+func (rs *RateLimitStore) RemoveHeldMessage(m *message.Message) error {
+	return rs.db.DeleteByKey(heldKey(m.Destination, m.Source, m.DepositNonce))
+}
+
+// ReleaseMessage marks the message as approved so that it is relayed regardless of rate limits
+func (rs *RateLimitStore) ReleaseMessage(m *message.Message) error {
+	return rs.db.SetByKey(releasedKey(m.Destination, m.Source, m.DepositNonce), []byte{1})
+}
+
+// IsReleased checks if the message was approved to bypass rate limits
+func (rs *RateLimitStore) IsReleased(m *message.Message) (bool, error) {
+	_, err := rs.db.GetByKey(releasedKey(m.Destination, m.Source, m.DepositNonce))
+	if err != nil {
+		if errors.Is(err, leveldb.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// RemoveRelease deletes the release approval of the message
+func (rs *RateLimitStore) RemoveRelease(m *message.Message) error {
+	return rs.db.DeleteByKey(releasedKey(m.Destination, m.Source, m.DepositNonce))
+}
+
+// RetractMessage removes the held message together with its release approval and the rate limit
+// usage it was counted with, which is needed when its deposit was reorged out of the source chain
+func (rs *RateLimitStore) RetractMessage(m *message.Message) error {
+	err := rs.RemoveHeldMessage(m)
+	if err != nil {
+		return err
+	}
+	err = rs.RemoveRelease(m)
+	if err != nil {
+		return err
+	}
+	return rs.db.DeleteByKey(transferKey(m.Destination, m.ResourceId, m.Source, m.DepositNonce))
+}
+
+func (rs *RateLimitStore) heldMessagesByPrefix(prefix string) ([]*HeldMessage, error) {
+	values, err := rs.db.GetByPrefix([]byte(prefix))
+	if err != nil {
+		return nil, err
+	}
+
+	msgs := make([]*HeldMessage, len(values))
+	for i, v := range values {
+		msgs[i] = &HeldMessage{}
+		err = decode(v, msgs[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return msgs, nil
+}
+
+func transferKey(destination uint8, resourceID [32]byte, source uint8, depositNonce uint64) []byte {
+	return []byte(fmt.Sprintf("ratelimit:%03d:%s:%03d:%020d", destination, hexutil.Encode(resourceID[:]), source, depositNonce))
+}
+
+func heldKey(destination, source uint8, depositNonce uint64) []byte {
+	return []byte(fmt.Sprintf("held:%03d:%03d:%020d", destination, source, depositNonce))
+}
+
+func releasedKey(destination, source uint8, depositNonce uint64) []byte {
+	return []byte(fmt.Sprintf("released:%03d:%03d:%020d", destination, source, depositNonce))
+}
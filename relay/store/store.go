@@ -13,8 +13,10 @@ type KeyValueReaderWriter interface {
 
 type KeyValueReader interface {
 	GetByKey(key []byte) ([]byte, error)
+	GetByPrefix(prefix []byte) ([][]byte, error)
 }
 
 type KeyValueWriter interface {
 	SetByKey(key []byte, value []byte) error
+	DeleteByKey(key []byte) error
 }
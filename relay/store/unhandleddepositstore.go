@@ -0,0 +1,80 @@
+package store
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// UnhandledDeposit is a deposit the listener could not turn into a message, for example
+// because no handler is registered for its resource ID
+type UnhandledDeposit struct {
+	Source          uint8
+	Destination     uint8
+	DepositNonce    uint64
+	ResourceID      [32]byte
+	Data            []byte
+	HandlerResponse []byte
+	// StartBlock and EndBlock are the block range in which the deposit was found
+	StartBlock  *big.Int
+	EndBlock    *big.Int
+	BlockNumber uint64
+	TxHash      common.Hash
+	Index       uint
+	Error       string
+	RecordedAt  time.Time
+}
+
+type UnhandledDepositStore struct {
+	db KeyValueReaderWriter
+}
+
+func NewUnhandledDepositStore(db KeyValueReaderWriter) *UnhandledDepositStore {
+	return &UnhandledDepositStore{
+		db: db,
+	}
+}
+
+// StoreUnhandledDeposit persists the unhandled deposit, replacing an earlier record of the same deposit
+func (us *UnhandledDepositStore) StoreUnhandledDeposit(d *UnhandledDeposit) error {
+	value, err := encode(d)
+	if err != nil {
+		return err
+	}
+	return us.db.SetByKey(unhandledDepositKey(d.Source, d.Destination, d.DepositNonce), value)
+}
+
+// UnhandledDeposits returns unhandled deposits from the source domain ordered by
+// destination domain and deposit nonce
+func (us *UnhandledDepositStore) UnhandledDeposits(source uint8) ([]*UnhandledDeposit, error) {
+	return us.unhandledDepositsByPrefix(fmt.Sprintf("unhandled:%03d:", source))
+}
+
+// AllUnhandledDeposits returns unhandled deposits from all source domains
+func (us *UnhandledDepositStore) AllUnhandledDeposits() ([]*UnhandledDeposit, error) {
+	return us.unhandledDepositsByPrefix("unhandled:")
+}
+
+func (us *UnhandledDepositStore) unhandledDepositsByPrefix(prefix string) ([]*UnhandledDeposit, error) {
+	values, err := us.db.GetByPrefix([]byte(prefix))
+	if err != nil {
+		return nil, err
+	}
+
+	deposits := make([]*UnhandledDeposit, len(values))
+	for i, v := range values {
+		deposits[i] = &UnhandledDeposit{}
+		err = decode(v, deposits[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return deposits, nil
+}
+
+// unhandledDepositKey is zero padded so that deposits of a source are ordered by destination and deposit nonce
+func unhandledDepositKey(source, destination uint8, depositNonce uint64) []byte {
+	return []byte(fmt.Sprintf("unhandled:%03d:%03d:%020d", source, destination, depositNonce))
+}